@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+var absurdleCommand = &cli.Command{
+	Name:  "absurdle",
+	Usage: "play against, or solve, an adversarial host that never commits to an answer",
+	Flags: []cli.Flag{
+		dictFlag(),
+		answersFlag(),
+		&cli.BoolFlag{
+			Name:  "solve",
+			Usage: "If we should search for the shortest forced win instead of hosting a game.",
+		},
+		&cli.StringSliceFlag{
+			Name:  "start",
+			Usage: "Guesses the solver must open with (can be multiple!)",
+		},
+		&cli.IntFlag{
+			Name:  "depth",
+			Usage: "The most guesses the solver will consider.",
+			Value: 6,
+		},
+		&cli.IntFlag{
+			Name:  "beam",
+			Usage: "The number of guesses the solver tries at each turn, best first.",
+			Value: 10,
+		},
+	},
+	Action: absurdleAction,
+}
+
+func absurdleAction(ctx *cli.Context) error {
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	answers, err := getAnswers(ctx.String("answers"), dict)
+	if err != nil {
+		return err
+	}
+	if len(answers) == 0 {
+		return fmt.Errorf("the answer list is empty")
+	}
+	if !ctx.Bool("solve") {
+		return hostAbsurdle(dict, wordRunes(answers), os.Stdin, os.Stdout)
+	}
+
+	solver := &absurdleSolver{
+		Guesses:  wordRunes(sortedWords(dict)),
+		MaxDepth: ctx.Int("depth"),
+		Beam:     ctx.Int("beam"),
+	}
+	candidates := wordRunes(answers)
+	var start []string
+	for _, word := range ctx.StringSlice("start") {
+		if !dict.Has(word) {
+			return fmt.Errorf("start word %q is not in the dictionary", word)
+		}
+		var feedback []byte
+		if feedback, candidates = absurdleBucket([]rune(word), candidates); feedback == nil {
+			return fmt.Errorf("no answers remain for start word %q", word)
+		}
+		start = append(start, word)
+	}
+	solution, ok := solver.Solve(start, candidates)
+	if !ok {
+		return fmt.Errorf("no forced win found within %d guesses", solver.MaxDepth)
+	}
+	candidates = wordRunes(answers)
+	for _, word := range solution {
+		var feedback []byte
+		if feedback, candidates = absurdleBucket([]rune(word), candidates); feedback == nil {
+			return fmt.Errorf("no answers remain for %q", word)
+		}
+		fmt.Printf("%s %s (%d remain)\n", word, formatFeedback(feedback), len(candidates))
+	}
+	fmt.Printf("forced win in %d\n", len(solution))
+	return nil
+}
+
+// hostAbsurdle plays an absurdle game reading guesses a line at a time.
+func hostAbsurdle(dict Set[string], candidates [][]rune, input io.Reader, output io.Writer) error {
+	if len(candidates) == 0 {
+		return fmt.Errorf("the answer list is empty")
+	}
+	fmt.Fprintf(output, "the host is holding %d words; make a guess\n", len(candidates))
+	scanner := bufio.NewScanner(input)
+	var turns int
+	for scanner.Scan() {
+		guess := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if guess == "" {
+			continue
+		}
+		if !dict.Has(guess) {
+			fmt.Fprintf(output, "%q is not in the dictionary\n", guess)
+			continue
+		}
+		turns++
+		var feedback []byte
+		feedback, candidates = absurdleBucket([]rune(guess), candidates)
+		fmt.Fprintf(output, "%s (%d remain)\n", renderFeedback([]rune(guess), feedback), len(candidates))
+		if allGreen(feedback) {
+			fmt.Fprintf(output, "solved in %d\n", turns)
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "gave up with %d words remaining\n", len(candidates))
+	return nil
+}
+
+// absurdleBucket returns the feedback an adversarial host gives for
+// a guess, along with the candidates consistent with that feedback;
+// with no candidates there is no feedback, and both are nil.
+//
+// the host picks the feedback that keeps the most candidates, breaking
+// ties in favor of the feedback with the lowest code; as the first tile
+// is the most significant, that's the feedback whose first differing
+// tile is worse (e.g. 'xxxgg' before 'yxxxx'), not the fewest greens
+// and yellows overall.
+func absurdleBucket(guess []rune, candidates [][]rune) ([]byte, [][]rune) {
+	buckets := make(map[int][][]rune)
+	for _, candidate := range candidates {
+		code := feedbackCode(guess, candidate)
+		buckets[code] = append(buckets[code], candidate)
+	}
+	bestCode := -1
+	for code, bucket := range buckets {
+		if bestCode == -1 || len(bucket) > len(buckets[bestCode]) || (len(bucket) == len(buckets[bestCode]) && code < bestCode) {
+			bestCode = code
+		}
+	}
+	if bestCode == -1 {
+		return nil, nil
+	}
+	bucket := buckets[bestCode]
+	return computeFeedback(guess, bucket[0]), bucket
+}
+
+// absurdleBucketSize is absurdleBucket without keeping the bucket.
+func absurdleBucketSize(guess []rune, candidates [][]rune) (code, size int) {
+	counts := partitionCounts(guess, candidates)
+	code = -1
+	for c, count := range counts {
+		if code == -1 || count > size || (count == size && c < code) {
+			code, size = c, count
+		}
+	}
+	return
+}
+
+// absurdleSolver searches for the shortest sequence of guesses that
+// wins no matter how the adversarial host plays.
+//
+// the search is a depth first branch and bound over the `Beam` guesses
+// that leave the smallest bucket at each turn, so it is not exhaustive.
+type absurdleSolver struct {
+	Guesses  [][]rune
+	MaxDepth int
+	Beam     int
+
+	best []string
+}
+
+// Solve returns the shortest forced win found that begins with the given path.
+func (as *absurdleSolver) Solve(path []string, candidates [][]rune) ([]string, bool) {
+	as.best = nil
+	as.search(path, candidates)
+	return as.best, as.best != nil
+}
+
+func (as *absurdleSolver) bound() int {
+	if as.best != nil {
+		return len(as.best)
+	}
+	return as.MaxDepth + 1
+}
+
+func (as *absurdleSolver) search(path []string, candidates [][]rune) {
+	if len(candidates) == 0 {
+		return
+	}
+	if len(candidates) == 1 {
+		if len(path)+1 < as.bound() {
+			as.best = append(append([]string(nil), path...), string(candidates[0]))
+		}
+		return
+	}
+	// we need at least one more guess to narrow the candidates,
+	// and one more to win.
+	if len(path)+2 >= as.bound() {
+		return
+	}
+	type option struct {
+		Guess []rune
+		Size  int
+	}
+	options := make([]option, len(as.Guesses))
+	parallelFor(len(as.Guesses), func(index int) {
+		_, size := absurdleBucketSize(as.Guesses[index], candidates)
+		options[index] = option{Guess: as.Guesses[index], Size: size}
+	})
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].Size < options[j].Size
+	})
+	for index, o := range options {
+		if index >= as.Beam || o.Size >= len(candidates) {
+			break
+		}
+		feedback, bucket := absurdleBucket(o.Guess, candidates)
+		nextPath := append(append([]string(nil), path...), string(o.Guess))
+		if allGreen(feedback) {
+			if len(nextPath) < as.bound() {
+				as.best = nextPath
+			}
+			continue
+		}
+		as.search(nextPath, bucket)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_absurdleBucket(t *testing.T) {
+	candidates := wordRunes([]string{"crane", "crate", "slosh", "dumpy", "fizzy"})
+
+	feedback, bucket := absurdleBucket([]rune("crane"), candidates)
+	if formatFeedback(feedback) != "xxxxx" {
+		t.Fatalf("expect the host to dodge every letter, got %s", formatFeedback(feedback))
+	}
+	if len(bucket) != 3 {
+		t.Fatalf("expect 3 candidates to remain, got %d", len(bucket))
+	}
+}
+
+func Test_absurdleSolver(t *testing.T) {
+	words := []string{"crane", "crate", "slosh", "dumpy", "fizzy"}
+	solver := &absurdleSolver{
+		Guesses:  wordRunes(words),
+		MaxDepth: 6,
+		Beam:     5,
+	}
+	solution, ok := solver.Solve(nil, wordRunes(words))
+	if !ok {
+		t.Fatalf("expect a forced win to be found")
+	}
+
+	candidates := wordRunes(words)
+	var feedback []byte
+	for _, word := range solution {
+		feedback, candidates = absurdleBucket([]rune(word), candidates)
+	}
+	if !allGreen(feedback) {
+		t.Fatalf("expect the solution %v to win", solution)
+	}
+}
+
+func Test_hostAbsurdle(t *testing.T) {
+	words := []string{"crane", "slosh"}
+	output := new(bytes.Buffer)
+	err := hostAbsurdle(NewSet(words), wordRunes(words), strings.NewReader("zzzzz\ncrane\nslosh\n"), output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output.String(), "not in the dictionary") {
+		t.Fatalf("expect invalid guesses to be rejected")
+	}
+	if !strings.Contains(output.String(), "solved in 2") {
+		t.Fatalf("expect the game to be solved in 2, got %s", output.String())
+	}
+}
+
+func Test_hostAbsurdle_noAnswers(t *testing.T) {
+	words := []string{"crane"}
+	err := hostAbsurdle(NewSet(words), nil, strings.NewReader("crane\n"), new(bytes.Buffer))
+	if err == nil {
+		t.Fatalf("expect an error for an empty answer list")
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// BLANK_CHAR is accepted as a blank alongside MASK_CHAR in letter sets.
+const BLANK_CHAR = '?'
+
+var anagramCommand = &cli.Command{
+	Name:      "anagram",
+	Usage:     "find dictionary words that are anagrams of a set of letters",
+	ArgsUsage: "[letters]",
+	Flags: []cli.Flag{
+		dictFlag(),
+		scorerFlag("heuristic"),
+		priorFlag("frequency"),
+		&cli.StringFlag{
+			Name:  "contains",
+			Usage: "Letters every result must contain (e.g. 'qu')",
+		},
+		&cli.IntFlag{
+			Name:  "length",
+			Usage: "The length of the results; for exact anagrams letters shorter than this are padded with blanks.",
+		},
+		&cli.BoolFlag{
+			Name:  "sub",
+			Usage: "If we should list every word that can be built from the letters, grouped by length.",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "If we should limit the number of results shown.",
+		},
+	},
+	Action: anagramAction,
+}
+
+func anagramAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("expected a single set of letters")
+	}
+	dict, weights, err := readDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	p, err := getPrior(ctx.String("prior"), weights)
+	if err != nil {
+		return err
+	}
+	newScore, err := getScorer(ctx.String("scorer"), p)
+	if err != nil {
+		return err
+	}
+
+	letters := normalizeLetters(ctx.Args().First())
+	contains := normalizeLetters(ctx.String("contains"))
+	length := ctx.Int("length")
+	if ctx.Bool("sub") {
+		matched := findSubAnagrams(sortedWords(dict), letters, contains)
+		candidates := wordRunes(matched)
+		ranked := rankGuesses(matched, candidates, newScore(candidates), nil)
+		for index, group := range groupByLength(ranked) {
+			groupLength := len([]rune(group[0].Word))
+			if length > 0 && groupLength != length {
+				continue
+			}
+			if index > 0 && length == 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%d letters:\n", groupLength)
+			printRanked(group, ctx.Int("limit"))
+		}
+		return nil
+	}
+
+	if length > 0 {
+		if length < len([]rune(letters)) {
+			return fmt.Errorf("length %d is shorter than the letters %q", length, letters)
+		}
+		letters += strings.Repeat(string(MASK_CHAR), length-len([]rune(letters)))
+	}
+	matched := findAnagrams(sortedWords(dict), letters, contains)
+
+	candidates := wordRunes(matched)
+	ranked := rankGuesses(matched, candidates, newScore(candidates), nil)
+	printRanked(ranked, ctx.Int("limit"))
+	return nil
+}
+
+// normalizeLetters lowercases letters and turns BLANK_CHAR into MASK_CHAR.
+func normalizeLetters(letters string) string {
+	return strings.ReplaceAll(strings.ToLower(letters), string(BLANK_CHAR), string(MASK_CHAR))
+}
+
+// findAnagrams returns the words that use every one of the letters
+// exactly, where MASK_CHAR in the letters stands for any letter.
+func findAnagrams(words []string, letters, contains string) []string {
+	length := len([]rune(letters))
+	letterCounts := runeCounts(letters)
+	blanks := strings.Count(letters, string(MASK_CHAR))
+	containsCounts := runeCounts(contains)
+
+	var output []string
+	for _, word := range words {
+		if len([]rune(word)) != length {
+			continue
+		}
+		wordCounts := runeCounts(word)
+		if !runeCountsWithinBlanks(wordCounts, letterCounts, blanks) {
+			continue
+		}
+		if !runeCountsWithin(containsCounts, wordCounts) {
+			continue
+		}
+		output = append(output, word)
+	}
+	return output
+}
+
+// findSubAnagrams returns the words that can be built from the letters,
+// using each letter at most as many times as it appears.
+func findSubAnagrams(words []string, letters, contains string) []string {
+	length := len([]rune(letters))
+	letterCounts := runeCounts(letters)
+	blanks := strings.Count(letters, string(MASK_CHAR))
+	containsCounts := runeCounts(contains)
+
+	var output []string
+	for _, word := range words {
+		if len([]rune(word)) > length {
+			continue
+		}
+		wordCounts := runeCounts(word)
+		if !runeCountsWithinBlanks(wordCounts, letterCounts, blanks) {
+			continue
+		}
+		if !runeCountsWithin(containsCounts, wordCounts) {
+			continue
+		}
+		output = append(output, word)
+	}
+	return output
+}
+
+// groupByLength splits ranked words into groups of the same length,
+// longest first, keeping the ranked order within each group.
+func groupByLength(ranked []wordWithScore) [][]wordWithScore {
+	byLength := make(map[int][]wordWithScore)
+	var lengths []int
+	for _, ws := range ranked {
+		length := len([]rune(ws.Word))
+		if _, ok := byLength[length]; !ok {
+			lengths = append(lengths, length)
+		}
+		byLength[length] = append(byLength[length], ws)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(lengths)))
+	output := make([][]wordWithScore, len(lengths))
+	for index, length := range lengths {
+		output[index] = byLength[length]
+	}
+	return output
+}
+
+// runeCountsWithinBlanks returns if a is a subset of b, where up to
+// `blanks` letters of a may be missing from b.
+func runeCountsWithinBlanks(a, b map[rune]int, blanks int) bool {
+	if blanks == 0 {
+		return runeCountsWithin(a, b)
+	}
+	var missing int
+	for key, aCount := range a {
+		if bCount := b[key]; aCount > bCount {
+			missing += aCount - bCount
+			if missing > blanks {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// printRanked prints scored words in the same form as the root command.
+func printRanked(ranked []wordWithScore, limit int) {
+	for index, ws := range ranked {
+		if limit > 0 && index >= limit {
+			break
+		}
+		fmt.Printf("%s (%s)\n", ws.Word, formatScore(ws.Score))
+	}
+}
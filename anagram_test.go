@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_findAnagrams(t *testing.T) {
+	words := []string{"aster", "rates", "tears", "tares", "stare", "steer", "quest", "squat"}
+
+	matched := findAnagrams(words, "tesra", "")
+	if strings.Join(matched, ",") != "aster,rates,tears,tares,stare" {
+		t.Fatalf("expect exact anagrams, got %v", matched)
+	}
+
+	matched = findAnagrams(words, normalizeLetters("?u?s?"), "q")
+	if strings.Join(matched, ",") != "quest,squat" {
+		t.Fatalf("expect blanks to match any letter, got %v", matched)
+	}
+}
+
+func Test_runeCountsWithinBlanks(t *testing.T) {
+	if !runeCountsWithinBlanks(runeCounts("aab"), runeCounts("ab"), 1) {
+		t.Fatalf("expect a single missing letter to be covered by a blank")
+	}
+	if runeCountsWithinBlanks(runeCounts("aabb"), runeCounts("ab"), 1) {
+		t.Fatalf("expect two missing letters to not be covered by a single blank")
+	}
+}
+
+func Test_findSubAnagrams(t *testing.T) {
+	words := []string{"a", "at", "cat", "coat", "coast", "toot", "zebra"}
+
+	matched := findSubAnagrams(words, "tacos", "")
+	if strings.Join(matched, ",") != "a,at,cat,coat,coast" {
+		t.Fatalf("expect every word buildable from the rack, got %v", matched)
+	}
+
+	matched = findSubAnagrams(words, normalizeLetters("to?"), "")
+	if strings.Join(matched, ",") != "a,at" {
+		t.Fatalf("expect each letter to be used at most once, got %v", matched)
+	}
+}
+
+func Test_groupByLength(t *testing.T) {
+	groups := groupByLength([]wordWithScore{
+		{Word: "at", Score: 3},
+		{Word: "coast", Score: 2},
+		{Word: "cat", Score: 2},
+		{Word: "act", Score: 1},
+	})
+	if len(groups) != 3 {
+		t.Fatalf("expect 3 groups, got %d", len(groups))
+	}
+	if groups[0][0].Word != "coast" || groups[1][0].Word != "cat" || groups[1][1].Word != "act" {
+		t.Fatalf("expect groups longest first keeping rank order, got %v", groups)
+	}
+}
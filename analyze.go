@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/urfave/cli/v2"
+)
+
+var analyzeCommand = &cli.Command{
+	Name:      "analyze",
+	Usage:     "replay a finished game and rate each guess for skill and luck",
+	ArgsUsage: "[guesses...]",
+	Flags: []cli.Flag{
+		dictFlag(),
+		answersFlag(),
+		scorerFlag("entropy"),
+		priorFlag("frequency"),
+		&cli.StringFlag{
+			Name:     "answer",
+			Usage:    "The answer to the game.",
+			Required: true,
+		},
+	},
+	Action: analyzeAction,
+}
+
+func analyzeAction(ctx *cli.Context) error {
+	dict, weights, err := readDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	answers, weights, err := readAnswers(ctx.String("answers"), dict, weights)
+	if err != nil {
+		return err
+	}
+	p, err := getPrior(ctx.String("prior"), weights)
+	if err != nil {
+		return err
+	}
+	newScore, err := getScorer(ctx.String("scorer"), p)
+	if err != nil {
+		return err
+	}
+	guesses := ctx.Args().Slice()
+	if len(guesses) == 0 {
+		return fmt.Errorf("at least one guess is required")
+	}
+
+	turns, err := analyzeGame(guesses, ctx.String("answer"), sortedWords(dict), wordRunes(answers), newScore)
+	if err != nil {
+		return err
+	}
+	var totalSkill, totalLuck float64
+	for index, turn := range turns {
+		fmt.Printf("%d. %s %s: %d candidates, scored %s against %s (%s); skill %d, luck %d, %d remain\n",
+			index+1,
+			turn.Guess,
+			formatFeedback(turn.Feedback),
+			turn.Candidates,
+			formatScore(turn.Score),
+			formatScore(turn.BestScore),
+			turn.Best,
+			int(turn.Skill),
+			int(turn.Luck),
+			turn.Remaining,
+		)
+		totalSkill += turn.Skill
+		totalLuck += turn.Luck
+	}
+	fmt.Printf("skill %d, luck %d\n", int(totalSkill/float64(len(turns))), int(totalLuck/float64(len(turns))))
+	return nil
+}
+
+// analyzedTurn is the analysis of a single turn of a finished game.
+type analyzedTurn struct {
+	Guess      string
+	Feedback   []byte
+	Candidates int
+	Remaining  int
+	Score      float64
+	Best       string
+	BestScore  float64
+	Skill      float64
+	Luck       float64
+}
+
+// analyzeGame replays a game, rating each guess against the best
+// guess available at that turn under the given scorer.
+//
+// skill is the guess score as a share of the best score, and luck is
+// the share of candidates that would have left more words remaining
+// than the feedback we actually got (counting ties as half), both
+// scaled to 0-99 in the manner of the usual bot write-ups.
+func analyzeGame(guesses []string, answer string, dict []string, answers [][]rune, newScore newScorer) ([]analyzedTurn, error) {
+	answerRunes := []rune(answer)
+	if !containsWord(answers, answerRunes) {
+		return nil, fmt.Errorf("answer %q is not in the answer list", answer)
+	}
+	var b board
+	var output []analyzedTurn
+	for _, guess := range guesses {
+		guessRunes := []rune(guess)
+		if len(guessRunes) != len(answerRunes) {
+			return nil, fmt.Errorf("guess %q doesn't match the length of the answer %q", guess, answer)
+		}
+		candidates := b.Filter(answers)
+		score := newScore(candidates)
+		turn := analyzedTurn{
+			Guess:      guess,
+			Feedback:   computeFeedback(guessRunes, answerRunes),
+			Candidates: len(candidates),
+			Score:      score(guessRunes, candidates),
+		}
+
+		ranked := rankGuesses(dict, candidates, score, newProgress("ranking "+guess, len(dict)))
+		if len(ranked) > 0 {
+			turn.Best, turn.BestScore = ranked[0].Word, ranked[0].Score
+		}
+		switch {
+		case len(candidates) == 1 && allGreen(turn.Feedback):
+			turn.Skill = 99
+		case turn.BestScore > 0:
+			turn.Skill = math.Max(0, math.Min(99, 99*turn.Score/turn.BestScore))
+		}
+
+		counts := partitionCounts(guessRunes, candidates)
+		actual := counts[encodeFeedback(turn.Feedback)]
+		var luck float64
+		for _, count := range counts {
+			if count > actual {
+				luck += float64(count)
+			} else if count == actual {
+				luck += float64(count) / 2
+			}
+		}
+		turn.Luck = 99 * luck / float64(len(candidates))
+
+		_ = b.Add(guess, turn.Feedback)
+		turn.Remaining = len(b.Filter(candidates))
+		output = append(output, turn)
+	}
+	return output, nil
+}
+
+func containsWord(words [][]rune, word []rune) bool {
+	for _, w := range words {
+		if string(w) == string(word) {
+			return true
+		}
+	}
+	return false
+}
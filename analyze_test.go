@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func Test_analyzeGame(t *testing.T) {
+	dict := []string{"abide", "aside", "crane", "speed", "slosh"}
+	answers := wordRunes(dict)
+	newScore := func([][]rune) scorer { return scoreEntropy }
+
+	turns, err := analyzeGame([]string{"slosh", "abide"}, "abide", dict, answers, newScore)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(turns) != 2 {
+		t.Fatalf("expect 2 turns, got %d", len(turns))
+	}
+	if turns[0].Candidates != 5 || turns[0].Remaining != 2 {
+		t.Fatalf("expect 5 candidates narrowed to 2, got %d and %d", turns[0].Candidates, turns[0].Remaining)
+	}
+	if turns[0].Skill <= 0 || turns[0].Skill > 99 {
+		t.Fatalf("expect skill to be scaled to 0-99, got %v", turns[0].Skill)
+	}
+	if turns[1].Remaining != 1 || !allGreen(turns[1].Feedback) {
+		t.Fatalf("expect the last turn to solve the game")
+	}
+
+	if _, err := analyzeGame([]string{"crane"}, "zzzzz", dict, answers, newScore); err == nil {
+		t.Fatalf("expect an answer missing from the answer list to error")
+	}
+}
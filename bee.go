@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+var beeCommand = &cli.Command{
+	Name:      "bee",
+	Usage:     "solve a spelling bee from its seven letters",
+	ArgsUsage: "[letters]",
+	Flags: []cli.Flag{
+		dictFlag(),
+		&cli.StringFlag{
+			Name:  "center",
+			Usage: "The required center letter (optional, will use the first letter by default)",
+		},
+		&cli.IntFlag{
+			Name:  "min-length",
+			Usage: "The shortest word allowed.",
+			Value: 4,
+		},
+	},
+	Action: beeAction,
+}
+
+func beeAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("expected a single set of letters")
+	}
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	letters := []rune(strings.ToLower(ctx.Args().First()))
+	if len(letters) == 0 {
+		return fmt.Errorf("expected a single set of letters")
+	}
+	center := letters[0]
+	if centerFlag := []rune(strings.ToLower(ctx.String("center"))); len(centerFlag) > 0 {
+		center = centerFlag[0]
+	}
+	if err := validateBeePuzzle(letters, center); err != nil {
+		return err
+	}
+
+	words := solveBee(sortedWords(dict), letters, center, ctx.Int("min-length"))
+	var total, pangrams int
+	for _, word := range words {
+		var marker string
+		if word.Pangram {
+			marker = " *"
+			pangrams++
+		}
+		fmt.Printf("%s (%d)%s\n", word.Word, word.Score, marker)
+		total += word.Score
+	}
+	fmt.Printf("\n%d words, %d pangrams, %d points\n", len(words), pangrams, total)
+	for _, rank := range beeRanks(total) {
+		fmt.Printf("%s: %d\n", rank.Name, rank.Points)
+	}
+	return nil
+}
+
+// beeLetterCount is the number of distinct letters in a spelling bee.
+const beeLetterCount = 7
+
+// validateBeePuzzle returns an error unless there are exactly seven
+// distinct letters and the center letter is one of them.
+func validateBeePuzzle(letters []rune, center rune) error {
+	distinct := NewSet(letters)
+	if len(distinct) != beeLetterCount {
+		return fmt.Errorf("expected %d distinct letters, got %d in %q", beeLetterCount, len(distinct), string(letters))
+	}
+	if !distinct.Has(center) {
+		return fmt.Errorf("the center letter %q is not one of the letters %q", center, string(letters))
+	}
+	return nil
+}
+
+// beeWord is a spelling bee answer and its score.
+type beeWord struct {
+	Word    string
+	Score   int
+	Pangram bool
+}
+
+// solveBee returns the words made only of the given letters that use
+// the center letter, highest scoring first; the center letter is taken
+// to be one of the letters (see validateBeePuzzle).
+func solveBee(words []string, letters []rune, center rune, minLength int) []beeWord {
+	allowed := NewSet(letters)
+
+	var output []beeWord
+	for _, word := range words {
+		runes := []rune(word)
+		if len(runes) < minLength {
+			continue
+		}
+		used := make(Set[rune])
+		valid := true
+		for _, r := range runes {
+			if !allowed.Has(r) {
+				valid = false
+				break
+			}
+			used.Add(r)
+		}
+		if !valid || !used.Has(center) {
+			continue
+		}
+		pangram := len(used) == len(allowed)
+		output = append(output, beeWord{
+			Word:    word,
+			Score:   scoreBeeWord(len(runes), pangram),
+			Pangram: pangram,
+		})
+	}
+	sort.SliceStable(output, func(i, j int) bool {
+		return output[i].Score > output[j].Score
+	})
+	return output
+}
+
+// scoreBeeWord scores a word under the official rules; four letter
+// words are worth a single point, longer words a point per letter, and
+// pangrams are worth an extra seven points.
+func scoreBeeWord(length int, pangram bool) int {
+	score := length
+	if length == 4 {
+		score = 1
+	}
+	if pangram {
+		score += 7
+	}
+	return score
+}
+
+// beeRank is a named rank and the points required to reach it.
+type beeRank struct {
+	Name   string
+	Points int
+}
+
+// beeRanks returns the rank thresholds for a puzzle worth a given total,
+// using the official percentages of the total rounded to the nearest point.
+func beeRanks(total int) []beeRank {
+	thresholds := []struct {
+		Name    string
+		Percent float64
+	}{
+		{"Beginner", 0},
+		{"Good Start", 2},
+		{"Moving Up", 5},
+		{"Good", 8},
+		{"Solid", 15},
+		{"Nice", 25},
+		{"Great", 40},
+		{"Amazing", 50},
+		{"Genius", 70},
+		{"Queen Bee", 100},
+	}
+	output := make([]beeRank, len(thresholds))
+	for index, threshold := range thresholds {
+		output[index] = beeRank{
+			Name:   threshold.Name,
+			Points: int(math.Round(float64(total) * threshold.Percent / 100)),
+		}
+	}
+	return output
+}
@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func Test_solveBee(t *testing.T) {
+	words := []string{"tine", "gain", "eating", "tearing", "granite", "train", "tat", "tinge", "baiting"}
+
+	results := solveBee(words, []rune("gainert"), 't', 4)
+	expected := map[string]int{
+		"tearing": 14,
+		"granite": 14,
+		"eating":  6,
+		"tinge":   5,
+		"train":   5,
+		"tine":    1,
+	}
+	if len(results) != len(expected) {
+		t.Fatalf("expect %d words, got %v", len(expected), results)
+	}
+	for _, result := range results {
+		if expected[result.Word] != result.Score {
+			t.Fatalf("expect %s to score %d, got %d", result.Word, expected[result.Word], result.Score)
+		}
+		if result.Pangram != (result.Score == 14) {
+			t.Fatalf("expect %s pangram to be %v", result.Word, result.Score == 14)
+		}
+	}
+	if results[0].Word != "tearing" {
+		t.Fatalf("expect results highest scoring first, got %s", results[0].Word)
+	}
+}
+
+func Test_solveBee_minLength(t *testing.T) {
+	results := solveBee([]string{"tine", "train", "eating"}, []rune("gainert"), 't', 5)
+	if len(results) != 2 || results[1].Word != "train" || results[1].Score != 5 {
+		t.Fatalf("expect the minimum length to filter words without changing their scores, got %v", results)
+	}
+}
+
+func Test_validateBeePuzzle(t *testing.T) {
+	if err := validateBeePuzzle([]rune("gainert"), 't'); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateBeePuzzle([]rune("gainert"), 'z'); err == nil {
+		t.Fatalf("expect a center letter outside the letters to error")
+	}
+	if err := validateBeePuzzle([]rune("gainerr"), 'g'); err == nil {
+		t.Fatalf("expect fewer than seven distinct letters to error")
+	}
+	if err := validateBeePuzzle([]rune("gainerts"), 'g'); err == nil {
+		t.Fatalf("expect more than seven distinct letters to error")
+	}
+}
+
+func Test_beeRanks(t *testing.T) {
+	ranks := beeRanks(200)
+	if ranks[0].Points != 0 || ranks[len(ranks)-2].Name != "Genius" || ranks[len(ranks)-2].Points != 140 || ranks[len(ranks)-1].Points != 200 {
+		t.Fatalf("unexpected ranks %v", ranks)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// board is the state for a single hidden word, given as the guesses
+// played against it and the feedback each received.
+type board struct {
+	Guesses  []string
+	Feedback [][]byte
+}
+
+// Add records a guess and its feedback.
+func (b *board) Add(guess string, feedback []byte) error {
+	if len([]rune(guess)) != len(feedback) {
+		return fmt.Errorf("feedback %q doesn't match the length of guess %q", formatFeedback(feedback), guess)
+	}
+	b.Guesses = append(b.Guesses, guess)
+	b.Feedback = append(b.Feedback, feedback)
+	return nil
+}
+
+// Solved returns if any guess received all greens.
+func (b board) Solved() bool {
+	for _, feedback := range b.Feedback {
+		if allGreen(feedback) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns if a word would have produced the same feedback
+// for every guess played against the board.
+func (b board) Matches(word []rune) bool {
+	for index, guess := range b.Guesses {
+		guessRunes := []rune(guess)
+		if len(guessRunes) != len(word) {
+			return false
+		}
+		if feedbackCode(guessRunes, word) != encodeFeedback(b.Feedback[index]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchesWithLies returns if a word would have produced feedback that
+// differs from every row in exactly `lies` tiles.
+//
+// a row that's all green is taken as the truth, as a solved row can't lie.
+func (b board) MatchesWithLies(word []rune, lies int) bool {
+	var stack [16]byte
+	for index, guess := range b.Guesses {
+		guessRunes := []rune(guess)
+		if len(guessRunes) != len(word) || len(guessRunes) > len(stack) {
+			return false
+		}
+		actual := stack[:len(guessRunes)]
+		fillFeedback(actual, guessRunes, word)
+		expected := b.Feedback[index]
+		if allGreen(expected) || allGreen(actual) {
+			if allGreen(expected) != allGreen(actual) {
+				return false
+			}
+			continue
+		}
+		var differs int
+		for position := range actual {
+			if actual[position] != expected[position] {
+				differs++
+			}
+		}
+		if differs != lies {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the candidates that match the board.
+func (b board) Filter(candidates [][]rune) [][]rune {
+	var output [][]rune
+	for _, candidate := range candidates {
+		if b.Matches(candidate) {
+			output = append(output, candidate)
+		}
+	}
+	return output
+}
+
+// Constraints derives the green mask, yellow masks and gray letters
+// in the same form as the `--green`, `--yellow` and `--gray` flags.
+//
+// a gray letter is only excluded outright if the same guess didn't also
+// mark another copy of that letter green or yellow.
+func (b board) Constraints() (green []rune, yellows []string, gray []rune) {
+	grays := make(Set[rune])
+	for index, guess := range b.Guesses {
+		guessRunes := []rune(guess)
+		feedback := b.Feedback[index]
+		if green == nil {
+			green = []rune(strings.Repeat(string(MASK_CHAR), len(guessRunes)))
+		}
+		present := make(Set[rune])
+		yellow := []rune(strings.Repeat(string(MASK_CHAR), len(guessRunes)))
+		var hasYellow bool
+		for position, r := range guessRunes {
+			switch feedback[position] {
+			case feedbackGreen:
+				if position < len(green) {
+					green[position] = r
+				}
+				present.Add(r)
+			case feedbackYellow:
+				yellow[position] = r
+				hasYellow = true
+				present.Add(r)
+			}
+		}
+		if hasYellow {
+			yellows = append(yellows, string(yellow))
+		}
+		for position, r := range guessRunes {
+			if feedback[position] == feedbackGray && !present.Has(r) && !grays.Has(r) {
+				grays.Add(r)
+				gray = append(gray, r)
+			}
+		}
+	}
+	return
+}
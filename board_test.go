@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_board_Constraints(t *testing.T) {
+	var b board
+	_ = b.Add("speed", computeFeedback([]rune("speed"), []rune("abide")))
+	_ = b.Add("eerie", computeFeedback([]rune("eerie"), []rune("abide")))
+
+	green, yellows, gray := b.Constraints()
+	if string(green) != "____e" {
+		t.Fatalf("expect green to be ____e, got %s", string(green))
+	}
+	if strings.Join(yellows, ",") != "__e_d,___i_" {
+		t.Fatalf("expect yellows to be __e_d,___i_, got %s", strings.Join(yellows, ","))
+	}
+	if string(gray) != "spr" {
+		t.Fatalf("expect gray to exclude repeated letters that were also yellow, got %s", string(gray))
+	}
+	if !b.Matches([]rune("abide")) {
+		t.Fatalf("expect the answer to match the board")
+	}
+	if b.Matches([]rune("aside")) {
+		t.Fatalf("expect aside to not match the board")
+	}
+}
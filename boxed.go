@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+var boxedCommand = &cli.Command{
+	Name:      "boxed",
+	Usage:     "solve a letter boxed puzzle from its four sides",
+	ArgsUsage: "[side] [side] [side] [side]",
+	Flags: []cli.Flag{
+		dictFlag(),
+		&cli.IntFlag{
+			Name:  "max-words",
+			Usage: "The most words a solution may have.",
+			Value: 3,
+		},
+		&cli.IntFlag{
+			Name:  "min-length",
+			Usage: "The shortest word allowed.",
+			Value: 3,
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "The number of solutions to show for each number of words.",
+			Value: 10,
+		},
+	},
+	Action: boxedAction,
+}
+
+func boxedAction(ctx *cli.Context) error {
+	if ctx.NArg() != 4 {
+		return fmt.Errorf("expected four sides, e.g. 'abc def ghi jkl'")
+	}
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	puzzle, err := newLetterBox(ctx.Args().Slice())
+	if err != nil {
+		return err
+	}
+	words := puzzle.Words(sortedWords(dict), ctx.Int("min-length"))
+	solutions := puzzle.Solve(words, ctx.Int("max-words"))
+
+	limit := ctx.Int("limit")
+	for count := 1; count <= ctx.Int("max-words"); count++ {
+		var shown int
+		for _, solution := range solutions {
+			if len(solution) != count {
+				continue
+			}
+			if shown == 0 {
+				fmt.Printf("%d word solutions:\n", count)
+			}
+			if limit > 0 && shown >= limit {
+				fmt.Println("...")
+				break
+			}
+			fmt.Println(strings.Join(solution, " "))
+			shown++
+		}
+	}
+	if len(solutions) == 0 {
+		fmt.Printf("no solutions within %d words\n", ctx.Int("max-words"))
+	}
+	return nil
+}
+
+// newLetterBox returns a letter box from its sides.
+func newLetterBox(sides []string) (*letterBox, error) {
+	lb := &letterBox{
+		side: make(map[rune]int),
+		bit:  make(map[rune]int),
+	}
+	for sideIndex, side := range sides {
+		for _, r := range strings.ToLower(side) {
+			if _, ok := lb.side[r]; ok {
+				return nil, fmt.Errorf("letter %q appears on more than one side", r)
+			}
+			lb.side[r] = sideIndex
+			lb.bit[r] = len(lb.bit)
+		}
+	}
+	lb.full = (1 << len(lb.bit)) - 1
+	return lb, nil
+}
+
+// letterBox is a letter boxed puzzle; letters are arranged on the sides
+// of a square and consecutive letters of a word can't share a side.
+type letterBox struct {
+	side map[rune]int
+	bit  map[rune]int
+	full int
+}
+
+// Words returns the dictionary words that can be traced around the box.
+func (lb *letterBox) Words(words []string, minLength int) []string {
+	var output []string
+	for _, word := range words {
+		if len([]rune(word)) >= minLength && lb.Valid(word) {
+			output = append(output, word)
+		}
+	}
+	return output
+}
+
+// Valid returns if every letter of a word is on the box and no two
+// consecutive letters share a side.
+func (lb *letterBox) Valid(word string) bool {
+	previous := -1
+	for _, r := range word {
+		side, ok := lb.side[r]
+		if !ok || side == previous {
+			return false
+		}
+		previous = side
+	}
+	return true
+}
+
+// Mask returns the bitmask of box letters a word uses.
+func (lb *letterBox) Mask(word string) int {
+	var mask int
+	for _, r := range word {
+		mask |= 1 << lb.bit[r]
+	}
+	return mask
+}
+
+// Solve returns the chains of up to `maxWords` words, each starting with
+// the last letter of the one before, that use every letter on the box.
+//
+// solutions are ordered by the number of words, then total letters.
+func (lb *letterBox) Solve(words []string, maxWords int) [][]string {
+	masks := make(map[string]int, len(words))
+	byFirst := make(map[rune][]string)
+	for _, word := range words {
+		masks[word] = lb.Mask(word)
+		first := []rune(word)[0]
+		byFirst[first] = append(byFirst[first], word)
+	}
+
+	var output [][]string
+	var path []string
+	var walk func(mask int)
+	walk = func(mask int) {
+		if mask == lb.full {
+			output = append(output, append([]string(nil), path...))
+			return
+		}
+		if len(path) >= maxWords {
+			return
+		}
+		var next []string
+		if len(path) == 0 {
+			next = words
+		} else {
+			last := []rune(path[len(path)-1])
+			next = byFirst[last[len(last)-1]]
+		}
+		for _, word := range next {
+			if masks[word]|mask == mask {
+				continue
+			}
+			path = append(path, word)
+			walk(mask | masks[word])
+			path = path[:len(path)-1]
+		}
+	}
+	walk(0)
+
+	sort.SliceStable(output, func(i, j int) bool {
+		if len(output[i]) != len(output[j]) {
+			return len(output[i]) < len(output[j])
+		}
+		return len(strings.Join(output[i], "")) < len(strings.Join(output[j], ""))
+	})
+	return output
+}
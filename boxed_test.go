@@ -0,0 +1,40 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_letterBox(t *testing.T) {
+	lb, err := newLetterBox([]string{"rme", "wca", "lio", "ybt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !lb.Valid("towel") {
+		t.Fatalf("expect towel to be valid")
+	}
+	if lb.Valid("oil") {
+		t.Fatalf("expect oil to be invalid as its letters share a side")
+	}
+	if lb.Valid("zebra") {
+		t.Fatalf("expect zebra to be invalid as z isn't on the box")
+	}
+
+	words := lb.Words([]string{"ambit", "towel", "lycra", "lyric", "oil", "to"}, 3)
+	solutions := lb.Solve(words, 3)
+	if len(solutions) != 4 {
+		t.Fatalf("expect 4 solutions, got %v", solutions)
+	}
+	if strings.Join(solutions[0], " ") != "ambit towel lycra" {
+		t.Fatalf("expect ambit towel lycra, got %v", solutions[0])
+	}
+	if len(lb.Solve(words, 2)) != 0 {
+		t.Fatalf("expect no two word solutions")
+	}
+}
+
+func Test_newLetterBox_duplicate(t *testing.T) {
+	if _, err := newLetterBox([]string{"abc", "ade", "fgh", "ijk"}); err == nil {
+		t.Fatalf("expect a letter on two sides to error")
+	}
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// RUN_CHAR matches a run of zero or more letters in crossword patterns.
+const RUN_CHAR = '*'
+
+var crosswordCommand = &cli.Command{
+	Name:      "crossword",
+	Usage:     "find words matching a crossword pattern, or fill a set of crossing slots",
+	ArgsUsage: "[pattern]",
+	Description: "patterns use '_', '?' or '.' for any letter, '[aeiou]' for a letter class,\n" +
+		"'[^aeiou]' for a negated class and '*' for a run of zero or more letters, e.g. 'c[aeiou]*t'",
+	Flags: []cli.Flag{
+		dictFlag(),
+		&cli.StringSliceFlag{
+			Name:  "slot",
+			Usage: "A fixed length slot pattern to fill, numbered from 1 in the order given (can be multiple!)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "cross",
+			Usage: "A shared cell between slots in 'slot:position=slot:position' form, 1 based, e.g. '1:3=2:1' (can be multiple!)",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "If we should limit the number of results shown.",
+		},
+	},
+	Action: crosswordAction,
+}
+
+func crosswordAction(ctx *cli.Context) error {
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	words := sortedWords(dict)
+	limit := ctx.Int("limit")
+
+	if slotFlags := ctx.StringSlice("slot"); len(slotFlags) > 0 {
+		cw, err := newCrossword(slotFlags, ctx.StringSlice("cross"))
+		if err != nil {
+			return err
+		}
+		for index, fill := range cw.Solve(words, limit) {
+			if limit > 0 && index >= limit {
+				break
+			}
+			fmt.Println(strings.Join(fill, " "))
+		}
+		return nil
+	}
+
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("expected a single pattern or at least one --slot")
+	}
+	pattern, err := compilePattern(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	var shown int
+	for _, word := range words {
+		if !pattern.Matches([]rune(word)) {
+			continue
+		}
+		if limit > 0 && shown >= limit {
+			break
+		}
+		fmt.Println(word)
+		shown++
+	}
+	return nil
+}
+
+// patternToken is a single element of a crossword pattern.
+type patternToken struct {
+	Letters Set[rune]
+	Negate  bool
+	Any     bool
+	Run     bool
+}
+
+// Allows returns if the token can match a single rune.
+func (pt patternToken) Allows(r rune) bool {
+	if pt.Any || pt.Run {
+		return true
+	}
+	return pt.Letters.Has(r) != pt.Negate
+}
+
+// crosswordPattern is a compiled crossword pattern.
+type crosswordPattern []patternToken
+
+// compilePattern compiles a crossword pattern; see crosswordCommand
+// for the syntax.
+func compilePattern(input string) (crosswordPattern, error) {
+	var output crosswordPattern
+	runes := []rune(strings.ToLower(input))
+	for index := 0; index < len(runes); index++ {
+		switch r := runes[index]; r {
+		case MASK_CHAR, BLANK_CHAR, '.':
+			output = append(output, patternToken{Any: true})
+		case RUN_CHAR:
+			output = append(output, patternToken{Run: true})
+		case '[':
+			end := index + 1
+			for end < len(runes) && runes[end] != ']' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated letter class in pattern %q", input)
+			}
+			class := runes[index+1 : end]
+			token := patternToken{Letters: make(Set[rune])}
+			if len(class) > 0 && class[0] == '^' {
+				token.Negate = true
+				class = class[1:]
+			}
+			if len(class) == 0 {
+				return nil, fmt.Errorf("empty letter class in pattern %q", input)
+			}
+			for _, c := range class {
+				token.Letters.Add(c)
+			}
+			output = append(output, token)
+			index = end
+		case ']':
+			return nil, fmt.Errorf("unexpected ']' in pattern %q", input)
+		default:
+			output = append(output, patternToken{Letters: NewSet([]rune{r})})
+		}
+	}
+	return output, nil
+}
+
+// FixedLength returns the length of words the pattern matches, if
+// the pattern has no runs.
+func (cp crosswordPattern) FixedLength() (int, bool) {
+	for _, token := range cp {
+		if token.Run {
+			return 0, false
+		}
+	}
+	return len(cp), true
+}
+
+// Matches returns if a word matches the pattern.
+func (cp crosswordPattern) Matches(word []rune) bool {
+	if length, ok := cp.FixedLength(); ok && length != len(word) {
+		return false
+	}
+	return cp.matchesFrom(0, word, 0)
+}
+
+func (cp crosswordPattern) matchesFrom(tokenIndex int, word []rune, wordIndex int) bool {
+	if tokenIndex == len(cp) {
+		return wordIndex == len(word)
+	}
+	token := cp[tokenIndex]
+	if token.Run {
+		for end := wordIndex; end <= len(word); end++ {
+			if cp.matchesFrom(tokenIndex+1, word, end) {
+				return true
+			}
+		}
+		return false
+	}
+	if wordIndex == len(word) || !token.Allows(word[wordIndex]) {
+		return false
+	}
+	return cp.matchesFrom(tokenIndex+1, word, wordIndex+1)
+}
+
+// crossing is a cell shared by two slots, as zero based positions.
+type crossing struct {
+	Slot, Position           int
+	OtherSlot, OtherPosition int
+}
+
+// newCrossword returns a crossword from its slot patterns and crossings.
+func newCrossword(slots []string, crossings []string) (*crossword, error) {
+	cw := new(crossword)
+	for _, slot := range slots {
+		pattern, err := compilePattern(slot)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := pattern.FixedLength(); !ok {
+			return nil, fmt.Errorf("slot %q must be a fixed length", slot)
+		}
+		cw.Slots = append(cw.Slots, pattern)
+	}
+	for _, input := range crossings {
+		left, right, ok := strings.Cut(input, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid crossing %q; expected the form 'slot:position=slot:position'", input)
+		}
+		slot, position, err := cw.parseCell(left)
+		if err != nil {
+			return nil, err
+		}
+		otherSlot, otherPosition, err := cw.parseCell(right)
+		if err != nil {
+			return nil, err
+		}
+		cw.Crossings = append(cw.Crossings, crossing{slot, position, otherSlot, otherPosition})
+	}
+	return cw, nil
+}
+
+// crossword is a set of slots to fill, some of which share cells.
+type crossword struct {
+	Slots     []crosswordPattern
+	Crossings []crossing
+}
+
+func (cw *crossword) parseCell(input string) (slot, position int, err error) {
+	slotText, positionText, ok := strings.Cut(strings.TrimSpace(input), ":")
+	if !ok {
+		err = fmt.Errorf("invalid cell %q; expected the form 'slot:position'", input)
+		return
+	}
+	if slot, err = strconv.Atoi(slotText); err != nil {
+		return
+	}
+	if position, err = strconv.Atoi(positionText); err != nil {
+		return
+	}
+	slot, position = slot-1, position-1
+	if slot < 0 || slot >= len(cw.Slots) {
+		err = fmt.Errorf("cell %q refers to a slot that doesn't exist", input)
+		return
+	}
+	if position < 0 || position >= len(cw.Slots[slot]) {
+		err = fmt.Errorf("cell %q is outside of its slot", input)
+	}
+	return
+}
+
+// Solve returns the combinations of distinct words, one per slot in the
+// order the slots were given, that fill every slot and agree on every
+// shared cell, stopping after `limit` combinations if limit is positive.
+//
+// slots are filled most constrained first, checking crossings against
+// the slots already filled as each word is placed.
+func (cw *crossword) Solve(words []string, limit int) [][]string {
+	candidates := make([][][]rune, len(cw.Slots))
+	for slot, pattern := range cw.Slots {
+		for _, word := range words {
+			if runes := []rune(word); pattern.Matches(runes) {
+				candidates[slot] = append(candidates[slot], runes)
+			}
+		}
+	}
+	order := make([]int, len(cw.Slots))
+	for index := range order {
+		order[index] = index
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(candidates[order[i]]) < len(candidates[order[j]])
+	})
+
+	var output [][]string
+	filled := make([][]rune, len(cw.Slots))
+	used := make(Set[string])
+	var walk func(depth int) bool
+	walk = func(depth int) bool {
+		if depth == len(order) {
+			fill := make([]string, len(filled))
+			for index, word := range filled {
+				fill[index] = string(word)
+			}
+			output = append(output, fill)
+			return limit > 0 && len(output) >= limit
+		}
+		slot := order[depth]
+		for _, word := range candidates[slot] {
+			if used.Has(string(word)) || !cw.agrees(slot, word, filled) {
+				continue
+			}
+			filled[slot] = word
+			used.Add(string(word))
+			done := walk(depth + 1)
+			delete(used, string(word))
+			filled[slot] = nil
+			if done {
+				return true
+			}
+		}
+		return false
+	}
+	walk(0)
+	return output
+}
+
+// agrees returns if placing a word in a slot agrees with every
+// crossing shared with a slot that's already filled.
+func (cw *crossword) agrees(slot int, word []rune, filled [][]rune) bool {
+	for _, c := range cw.Crossings {
+		switch {
+		case c.Slot == slot && filled[c.OtherSlot] != nil:
+			if word[c.Position] != filled[c.OtherSlot][c.OtherPosition] {
+				return false
+			}
+		case c.OtherSlot == slot && filled[c.Slot] != nil:
+			if word[c.OtherPosition] != filled[c.Slot][c.Position] {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_compilePattern(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		word     string
+		expected bool
+	}{
+		{"c_t", "cat", true},
+		{"c?t", "cart", false},
+		{"c[aeiou]t", "cot", true},
+		{"c[^aeiou]t", "cot", false},
+		{"c*t", "ct", true},
+		{"c*t", "carrot", true},
+		{"c*t", "carrots", false},
+		{"*ing", "sing", true},
+		{"[abc]*[xyz]", "box", true},
+	}
+	for _, tc := range testCases {
+		pattern, err := compilePattern(tc.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := pattern.Matches([]rune(tc.word)); actual != tc.expected {
+			t.Fatalf("expect %s matching %s to be %v", tc.pattern, tc.word, tc.expected)
+		}
+	}
+
+	if _, err := compilePattern("c[aeiou"); err == nil {
+		t.Fatalf("expect an unterminated class to error")
+	}
+}
+
+func Test_crossword_Solve(t *testing.T) {
+	// c a t
+	// o . o
+	// w e b
+	words := []string{"cat", "cow", "tob", "web", "wet", "tow"}
+	cw, err := newCrossword(
+		[]string{"c__", "c__", "___", "t__"},
+		[]string{"1:1=2:1", "2:3=3:1", "1:3=4:1", "3:3=4:3"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fills := cw.Solve(words, 0)
+	if len(fills) != 1 || strings.Join(fills[0], " ") != "cat cow web tob" {
+		t.Fatalf("expect a single fill, got %v", fills)
+	}
+
+	if _, err := newCrossword([]string{"c*"}, nil); err == nil {
+		t.Fatalf("expect a variable length slot to error")
+	}
+	if _, err := newCrossword([]string{"c__"}, []string{"1:4=1:1"}); err == nil {
+		t.Fatalf("expect a crossing outside of its slot to error")
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// The colours a single tile of feedback can take.
+const (
+	feedbackGray   byte = 0
+	feedbackYellow byte = 1
+	feedbackGreen  byte = 2
+)
+
+// computeFeedback returns the tile colours for a guess played
+// against a given answer.
+//
+// greens are assigned first, then yellows are handed out left to right
+// for as many copies of a letter as remain unmatched in the answer.
+func computeFeedback(guess, answer []rune) []byte {
+	output := make([]byte, len(guess))
+	fillFeedback(output, guess, answer)
+	return output
+}
+
+// feedbackCode returns the feedback for a guess against a given answer
+// packed into a single base 3 integer, which is cheaper to bucket on
+// than the feedback itself.
+func feedbackCode(guess, answer []rune) int {
+	var stack [16]byte
+	var output []byte
+	if len(guess) <= len(stack) {
+		output = stack[:len(guess)]
+	} else {
+		output = make([]byte, len(guess))
+	}
+	fillFeedback(output, guess, answer)
+	return encodeFeedback(output)
+}
+
+func fillFeedback(output []byte, guess, answer []rune) {
+	var stack [16]bool
+	var used []bool
+	if len(answer) <= len(stack) {
+		used = stack[:len(answer)]
+	} else {
+		used = make([]bool, len(answer))
+	}
+	for index := range guess {
+		output[index] = feedbackGray
+		if index < len(answer) && guess[index] == answer[index] {
+			output[index] = feedbackGreen
+			used[index] = true
+		}
+	}
+	for index, r := range guess {
+		if output[index] == feedbackGreen {
+			continue
+		}
+		for answerIndex, a := range answer {
+			if !used[answerIndex] && a == r {
+				output[index] = feedbackYellow
+				used[answerIndex] = true
+				break
+			}
+		}
+	}
+}
+
+// encodeFeedback packs feedback into a base 3 integer.
+func encodeFeedback(feedback []byte) int {
+	var code int
+	for _, f := range feedback {
+		code = code*3 + int(f)
+	}
+	return code
+}
+
+// allGreen returns if every tile of the feedback is green.
+func allGreen(feedback []byte) bool {
+	for _, f := range feedback {
+		if f != feedbackGreen {
+			return false
+		}
+	}
+	return len(feedback) > 0
+}
+
+// parseFeedback parses a feedback string, one character per tile.
+//
+// greens are given as 'g', yellows as 'y', and grays as any of
+// 'x', 'b', '.', '-' or MASK_CHAR. The emoji squares from a shared
+// grid are also accepted (see feedbackEmoji).
+func parseFeedback(input string) ([]byte, error) {
+	var output []byte
+	for _, c := range strings.ToLower(input) {
+		switch c {
+		case 'g':
+			output = append(output, feedbackGreen)
+		case 'y':
+			output = append(output, feedbackYellow)
+		case 'x', 'b', '.', '-', MASK_CHAR:
+			output = append(output, feedbackGray)
+		case emojiVariationSelector:
+			continue
+		default:
+			if f, ok := feedbackEmoji(c); ok {
+				output = append(output, f)
+				continue
+			}
+			return nil, fmt.Errorf("invalid feedback character %q in %q", c, input)
+		}
+	}
+	return output, nil
+}
+
+// emojiVariationSelector can follow an emoji square when it's pasted.
+const emojiVariationSelector = '\uFE0F'
+
+// feedbackEmoji returns the feedback for an emoji square from a shared
+// grid, in either the light or dark theme and with high contrast colours.
+func feedbackEmoji(c rune) (byte, bool) {
+	switch c {
+	case '🟩', '🟧':
+		return feedbackGreen, true
+	case '🟨', '🟦':
+		return feedbackYellow, true
+	case '⬛', '⬜':
+		return feedbackGray, true
+	default:
+		return 0, false
+	}
+}
+
+// formatFeedback is the inverse of parseFeedback.
+func formatFeedback(feedback []byte) string {
+	var sb strings.Builder
+	for _, f := range feedback {
+		switch f {
+		case feedbackGreen:
+			sb.WriteRune('g')
+		case feedbackYellow:
+			sb.WriteRune('y')
+		default:
+			sb.WriteRune('x')
+		}
+	}
+	return sb.String()
+}
+
+// partitionCounts buckets the candidates by the feedback the guess
+// would receive against each of them.
+func partitionCounts(guess []rune, candidates [][]rune) map[int]int {
+	output := make(map[int]int)
+	for _, candidate := range candidates {
+		output[feedbackCode(guess, candidate)]++
+	}
+	return output
+}
+
+// entropy returns the shannon entropy in bits of a set of bucket sizes.
+//
+// the sizes are summed in sorted order so that the result doesn't
+// depend on map iteration order, which keeps ties between guesses stable.
+func entropy[K comparable](counts map[K]int) float64 {
+	var total int
+	sizes := make([]int, 0, len(counts))
+	for _, count := range counts {
+		total += count
+		sizes = append(sizes, count)
+	}
+	if total == 0 {
+		return 0
+	}
+	sort.Ints(sizes)
+	var output float64
+	for _, count := range sizes {
+		p := float64(count) / float64(total)
+		output -= p * math.Log2(p)
+	}
+	return output
+}
+
+// wordRunes converts a list of words into their rune slices up front
+// so that hot loops don't have to.
+func wordRunes(words []string) [][]rune {
+	output := make([][]rune, len(words))
+	for index, word := range words {
+		output[index] = []rune(word)
+	}
+	return output
+}
+
+// renderFeedback prints a guess with each letter coloured by its
+// feedback using ansi background colours.
+func renderFeedback(guess []rune, feedback []byte) string {
+	var sb strings.Builder
+	for index, r := range guess {
+		switch feedback[index] {
+		case feedbackGreen:
+			sb.WriteString("\033[30;42m")
+		case feedbackYellow:
+			sb.WriteString("\033[30;43m")
+		default:
+			sb.WriteString("\033[37;100m")
+		}
+		sb.WriteString(" " + strings.ToUpper(string(r)) + " ")
+		sb.WriteString("\033[0m")
+	}
+	return sb.String()
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func Test_computeFeedback(t *testing.T) {
+	testCases := []struct {
+		guess    string
+		answer   string
+		expected string
+	}{
+		{"crane", "crane", "ggggg"},
+		{"crane", "slosh", "xxxxx"},
+		{"speed", "abide", "xxyxy"},
+		{"eerie", "abide", "xxxyg"},
+		{"lolly", "hello", "xyggx"},
+	}
+	for _, tc := range testCases {
+		actual := formatFeedback(computeFeedback([]rune(tc.guess), []rune(tc.answer)))
+		if actual != tc.expected {
+			t.Fatalf("expect %s against %s to be %s, got %s", tc.guess, tc.answer, tc.expected, actual)
+		}
+	}
+}
+
+func Test_parseFeedback(t *testing.T) {
+	feedback, err := parseFeedback("GY.x-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if formatFeedback(feedback) != "gyxxx" {
+		t.Fatalf("expect feedback to round trip, got %s", formatFeedback(feedback))
+	}
+	if _, err := parseFeedback("gyz"); err == nil {
+		t.Fatalf("expect invalid feedback to error")
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/urfave/cli/v2"
+)
+
+var fibbleCommand = &cli.Command{
+	Name:  "fibble",
+	Usage: "solve fibble, where exactly one tile of each feedback row is a lie",
+	Flags: []cli.Flag{
+		dictFlag(),
+		answersFlag(),
+		&cli.StringSliceFlag{
+			Name:  "guess",
+			Usage: "A guess and its feedback as shown in 'word:feedback' form, e.g. 'crane:xgyxx' (can be multiple!)",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "The number of candidates and suggestions to show.",
+			Value: 10,
+		},
+	},
+	Action: fibbleAction,
+}
+
+func fibbleAction(ctx *cli.Context) error {
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	answers, err := getAnswers(ctx.String("answers"), dict)
+	if err != nil {
+		return err
+	}
+	var state solverState
+	for _, guess := range ctx.StringSlice("guess") {
+		if err := state.AddGuess(guess); err != nil {
+			return err
+		}
+	}
+	b, err := state.Board()
+	if err != nil {
+		return err
+	}
+
+	var candidates [][]rune
+	for _, answer := range wordRunes(answers) {
+		if b.MatchesWithLies(answer, 1) {
+			candidates = append(candidates, answer)
+		}
+	}
+	limit := ctx.Int("limit")
+	fmt.Printf("%d candidates\n", len(candidates))
+	for index, candidate := range candidates {
+		if limit > 0 && index >= limit {
+			fmt.Println("...")
+			break
+		}
+		fmt.Println(string(candidate))
+	}
+	if len(candidates) <= 1 {
+		return nil
+	}
+
+	guesses := sortedWords(dict)
+	ranked := rankGuesses(guesses, candidates, scoreFibble, newProgress("ranking guesses", len(guesses)))
+	fmt.Println("\nsuggestions:")
+	printRanked(ranked, limit)
+	return nil
+}
+
+// scoreFibble returns the expected information in bits a guess gives
+// about the answer when one tile of the feedback is a lie.
+//
+// the lie is taken to be equally likely to be any tile turned to either
+// of its other two colours, so each true feedback is seen as one of 2n
+// rows; the score is the entropy of the rows seen less the entropy the
+// lie itself adds, which is none for an answer that would solve it.
+func scoreFibble(guess []rune, candidates [][]rune) float64 {
+	if len(candidates) == 0 {
+		return 0
+	}
+	length := len(guess)
+	lies := 2 * length
+	solved := feedbackCode(guess, guess)
+
+	seen := make(map[int]int)
+	var solvedCount int
+	for code, count := range partitionCounts(guess, candidates) {
+		if code == solved {
+			seen[code] += count * lies
+			solvedCount += count
+			continue
+		}
+		place := 1
+		remaining := code
+		for position := 0; position < length; position++ {
+			digit := remaining % 3
+			remaining /= 3
+			for colour := 0; colour < 3; colour++ {
+				if colour != digit {
+					seen[code+(colour-digit)*place] += count
+				}
+			}
+			place *= 3
+		}
+	}
+	lying := float64(len(candidates)-solvedCount) / float64(len(candidates))
+	return entropy(seen) - lying*math.Log2(float64(lies))
+}
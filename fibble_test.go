@@ -0,0 +1,42 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_board_MatchesWithLies(t *testing.T) {
+	var b board
+	// against "cigar", crane is truly gyyxx; the second tile lies.
+	if err := b.Add("crane", []byte{feedbackGreen, feedbackGreen, feedbackYellow, feedbackGray, feedbackGray}); err != nil {
+		t.Fatal(err)
+	}
+	if !b.MatchesWithLies([]rune("cigar"), 1) {
+		t.Fatalf("expect cigar to match with one lie")
+	}
+	if b.MatchesWithLies([]rune("cigar"), 0) {
+		t.Fatalf("expect cigar to not match truthfully")
+	}
+	if b.MatchesWithLies([]rune("rebut"), 1) {
+		t.Fatalf("expect rebut to not match, as its feedback differs in four tiles")
+	}
+	if b.MatchesWithLies([]rune("crane"), 1) {
+		t.Fatalf("expect the guess itself to not match a row that isn't solved")
+	}
+}
+
+func Test_scoreFibble(t *testing.T) {
+	candidates := wordRunes([]string{"cigar", "rebut", "sissy"})
+	if score := scoreFibble([]rune("cigar"), candidates[:1]); score != 0 {
+		t.Fatalf("expect no information from a single candidate, got %v", score)
+	}
+	// the three true rows are all distinct and their lies never collide
+	// with another true row's lies here, so the full log2(3) is recovered.
+	score := scoreFibble([]rune("zzzzz"), wordRunes([]string{"aaaaa", "zzzaa", "zzzzz"}))
+	if math.Abs(score-math.Log2(3)) > 1e-9 {
+		t.Fatalf("expect log2(3) bits, got %v", score)
+	}
+	if scoreFibble([]rune("cigar"), candidates) <= 0 {
+		t.Fatalf("expect a positive score")
+	}
+}
@@ -0,0 +1,186 @@
+//go:build ignore
+
+// gen_dictionary rebuilds the weights in dictionary.txt from a corpus of
+// english text, keeping the words already in the dictionary.
+//
+// the weight of a word is 1 plus the number of documents in the corpus it
+// appears in at least once, where each paragraph of a file is a document;
+// counting documents rather than occurrences keeps words repeated by one
+// text (e.g. a character's name in a novel) or by markup (e.g. 'class' in
+// html) from dominating. only text, markdown, html and gzipped files (e.g.
+// manual pages) are read, and html is stripped of tags, scripts and styles.
+//
+// usage:
+//
+//	go run gen_dictionary.go [-dict dictionary.txt] [-source description] [corpus path] ... > dictionary.txt.new
+//
+// corpus paths may be files, directories or quoted glob patterns, and are
+// recorded as given in the header of the output; a general english corpus
+// gives the best weights. the embedded dictionary is weighted by Peter
+// Norvig's spelling corpus (public domain project gutenberg books and word
+// lists from wiktionary and the british national corpus), as shipped with
+// the MIT licensed github.com/sajari/fuzzy module:
+//
+//	go mod download github.com/sajari/fuzzy@v1.0.0
+//	cd $(go env GOMODCACHE) && go run $OLDPWD/gen_dictionary.go -dict $OLDPWD/dictionary.txt \
+//		-source "Peter Norvig's big.txt (public domain texts), via github.com/sajari/fuzzy (MIT)" \
+//		github.com/sajari/fuzzy@v1.0.0/data/big.txt > $OLDPWD/dictionary.txt.new
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var mainElement = regexp.MustCompile(`(?is)<main[^>]*>(.*)</main>`)
+
+// paragraphs are separated by blank lines.
+var paragraphs = regexp.MustCompile(`\n[ \t\r]*\n`)
+
+var markup = regexp.MustCompile(`(?is)<script.*?</script>|<style.*?</style>|<[^>]+>|&[a-z]+;|&#[0-9]+;`)
+
+func main() {
+	dictPath := flag.String("dict", "dictionary.txt", "The dictionary whose words are weighed.")
+	source := flag.String("source", "", "A description of the corpus, e.g. its title, version and license, for the header.")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: go run gen_dictionary.go [-dict dictionary.txt] [-source description] [corpus path] ...")
+		os.Exit(1)
+	}
+	words, err := readWords(*dictPath)
+	if err != nil {
+		fatal(err)
+	}
+	documents := make(map[string]int)
+	var documentCount int
+	var roots []string
+	for _, pattern := range flag.Args() {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			fatal(err)
+		}
+		roots = append(roots, matches...)
+	}
+	for _, root := range roots {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !isDocument(path) {
+				return err
+			}
+			text, err := readDocument(path)
+			if err != nil {
+				return err
+			}
+			for _, paragraph := range paragraphs.Split(text, -1) {
+				tokens := strings.FieldsFunc(paragraph, func(r rune) bool { return !unicode.IsLetter(r) })
+				if len(tokens) == 0 {
+					continue
+				}
+				documentCount++
+				seen := make(map[string]struct{})
+				for _, token := range tokens {
+					token = strings.ToLower(token)
+					if _, ok := words[token]; !ok {
+						continue
+					}
+					if _, ok := seen[token]; !ok {
+						seen[token] = struct{}{}
+						documents[token]++
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	sorted := make([]string, 0, len(words))
+	for word := range words {
+		sorted = append(sorted, word)
+	}
+	sort.Strings(sorted)
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	fmt.Fprintln(out, "# word<TAB>weight, where the weight is 1 plus the number of paragraphs the")
+	fmt.Fprintf(out, "# word appears in, out of %d paragraphs, as built by gen_dictionary.go from:\n", documentCount)
+	if *source != "" {
+		fmt.Fprintf(out, "#   %s\n", *source)
+	}
+	for _, pattern := range flag.Args() {
+		fmt.Fprintf(out, "#   %s\n", pattern)
+	}
+	for _, word := range sorted {
+		fmt.Fprintf(out, "%s\t%d\n", word, documents[word]+1)
+	}
+}
+
+// readWords reads the words of a dictionary, ignoring any weights.
+func readWords(path string) (map[string]struct{}, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	output := make(map[string]struct{})
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, _, _ := strings.Cut(line, "\t")
+		output[word] = struct{}{}
+	}
+	return output, nil
+}
+
+// isDocument reports whether a file is text, html or gzipped (e.g. a
+// manual page); anything else (stylesheets, scripts, fonts) is skipped.
+func isDocument(path string) bool {
+	switch filepath.Ext(path) {
+	case ".txt", ".md", ".html", ".htm", ".gz":
+		return true
+	}
+	return false
+}
+
+func readDocument(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(path, ".gz") {
+		r, err := gzip.NewReader(bytes.NewReader(contents))
+		if err != nil {
+			return "", nil
+		}
+		if contents, err = io.ReadAll(r); err != nil {
+			return "", nil
+		}
+	}
+	text := string(contents)
+	if strings.HasSuffix(path, ".html") || strings.HasSuffix(path, ".htm") {
+		// the navigation around a page's main element is the same on
+		// every page, so only the main element is read if there is one.
+		if match := mainElement.FindStringSubmatch(text); match != nil {
+			text = match[1]
+		}
+		text = markup.ReplaceAllString(text, " ")
+	}
+	return text, nil
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
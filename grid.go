@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/urfave/cli/v2"
+)
+
+var gridCommand = &cli.Command{
+	Name:      "grid",
+	Usage:     "find the words traceable through a letter grid (boggle, squaredle, word hunt)",
+	ArgsUsage: "[row] [row] ... (or rows separated by '/')",
+	Flags: []cli.Flag{
+		dictFlag(),
+		&cli.BoolFlag{
+			Name:  "diagonal",
+			Usage: "If diagonal cells are adjacent.",
+			Value: true,
+		},
+		&cli.BoolFlag{
+			Name:  "qu",
+			Usage: "If 'q' tiles should be read as 'qu'.",
+		},
+		&cli.IntFlag{
+			Name:  "min-length",
+			Usage: "The shortest word allowed.",
+			Value: 3,
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "The number of words to show for each length.",
+		},
+	},
+	Action: gridAction,
+}
+
+func gridAction(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return fmt.Errorf("expected the rows of the grid, e.g. 'abcd efgh ijkl mnop'")
+	}
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	var rows []string
+	for _, arg := range ctx.Args().Slice() {
+		rows = append(rows, strings.FieldsFunc(arg, func(r rune) bool {
+			return r == '/' || unicode.IsSpace(r)
+		})...)
+	}
+	g, err := newLetterGrid(rows, ctx.Bool("qu"))
+	if err != nil {
+		return err
+	}
+	g.Diagonal = ctx.Bool("diagonal")
+
+	words := g.Find(newTrie(sortedWords(dict)), ctx.Int("min-length"))
+	byLength := make(map[int][]string)
+	var lengths []int
+	var total int
+	for _, word := range words {
+		length := len([]rune(word))
+		if _, ok := byLength[length]; !ok {
+			lengths = append(lengths, length)
+		}
+		byLength[length] = append(byLength[length], word)
+		total += scoreBoggleWord(length)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(lengths)))
+	limit := ctx.Int("limit")
+	for _, length := range lengths {
+		fmt.Printf("%d letters (%d words, %d points each):\n", length, len(byLength[length]), scoreBoggleWord(length))
+		for index, word := range byLength[length] {
+			if limit > 0 && index >= limit {
+				fmt.Println("...")
+				break
+			}
+			fmt.Println(word)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%d words, %d points\n", len(words), total)
+	return nil
+}
+
+// newLetterGrid returns a grid from its rows, each a string of letters.
+func newLetterGrid(rows []string, qu bool) (*letterGrid, error) {
+	g := new(letterGrid)
+	for _, row := range rows {
+		var cells []string
+		for _, r := range strings.ToLower(row) {
+			cell := string(r)
+			if qu && r == 'q' {
+				cell = "qu"
+			}
+			cells = append(cells, cell)
+		}
+		if len(g.Cells) > 0 && len(cells) != len(g.Cells[0]) {
+			return nil, fmt.Errorf("row %q is a different width than the rows before it", row)
+		}
+		g.Cells = append(g.Cells, cells)
+	}
+	return g, nil
+}
+
+// letterGrid is an N×M grid of letter tiles, where a tile may hold
+// more than one letter (e.g. "qu").
+type letterGrid struct {
+	Cells    [][]string
+	Diagonal bool
+}
+
+// Find returns the sorted words in the trie that can be traced through
+// adjacent cells without reusing a cell.
+func (g *letterGrid) Find(words *trie, minLength int) []string {
+	found := make(Set[string])
+	visited := make([][]bool, len(g.Cells))
+	for row := range g.Cells {
+		visited[row] = make([]bool, len(g.Cells[row]))
+	}
+	var walk func(row, col int, node *trie)
+	walk = func(row, col int, node *trie) {
+		for _, r := range g.Cells[row][col] {
+			if node = node.Child(r); node == nil {
+				return
+			}
+		}
+		if word := node.Word(); word != "" && len([]rune(word)) >= minLength {
+			found.Add(word)
+		}
+		visited[row][col] = true
+		for _, next := range g.adjacent(row, col) {
+			if !visited[next[0]][next[1]] {
+				walk(next[0], next[1], node)
+			}
+		}
+		visited[row][col] = false
+	}
+	for row := range g.Cells {
+		for col := range g.Cells[row] {
+			walk(row, col, words)
+		}
+	}
+	return sortedWords(found)
+}
+
+func (g *letterGrid) adjacent(row, col int) [][2]int {
+	var output [][2]int
+	for dr := -1; dr <= 1; dr++ {
+		for dc := -1; dc <= 1; dc++ {
+			if dr == 0 && dc == 0 {
+				continue
+			}
+			if !g.Diagonal && dr != 0 && dc != 0 {
+				continue
+			}
+			r, c := row+dr, col+dc
+			if r < 0 || r >= len(g.Cells) || c < 0 || c >= len(g.Cells[r]) {
+				continue
+			}
+			output = append(output, [2]int{r, c})
+		}
+	}
+	return output
+}
+
+// scoreBoggleWord scores a word by its length under the boggle rules.
+func scoreBoggleWord(length int) int {
+	switch {
+	case length <= 4:
+		return 1
+	case length == 5:
+		return 2
+	case length == 6:
+		return 3
+	case length == 7:
+		return 5
+	default:
+		return 11
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_letterGrid_Find(t *testing.T) {
+	words := newTrie([]string{"cat", "cats", "act", "tact", "scat", "taco"})
+	g, err := newLetterGrid([]string{"ca", "ts"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g.Diagonal = true
+	if found := strings.Join(g.Find(words, 3), ","); found != "act,cat,cats,scat" {
+		t.Fatalf("expect words without reusing cells, got %s", found)
+	}
+	g.Diagonal = false
+	if found := strings.Join(g.Find(words, 3), ","); found != "act" {
+		t.Fatalf("expect only orthogonal paths, got %s", found)
+	}
+}
+
+func Test_letterGrid_qu(t *testing.T) {
+	words := newTrie([]string{"quit", "qit"})
+	g, err := newLetterGrid([]string{"qi", "xt"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Diagonal = true
+	if found := strings.Join(g.Find(words, 3), ","); found != "quit" {
+		t.Fatalf("expect q tiles to read as qu, got %s", found)
+	}
+	if _, err := newLetterGrid([]string{"abc", "de"}, false); err == nil {
+		t.Fatalf("expect ragged rows to error")
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+var hangmanCommand = &cli.Command{
+	Name:      "hangman",
+	Usage:     "filter words by a revealed hangman pattern and suggest the next letter",
+	ArgsUsage: "[pattern]",
+	Flags: []cli.Flag{
+		dictFlag(),
+		&cli.StringFlag{
+			Name:  "wrong",
+			Usage: "The letters guessed that aren't in the word (e.g. 'ergv')",
+		},
+		&cli.StringFlag{
+			Name:  "strategy",
+			Usage: "How letters are ranked, one of 'frequency' or 'entropy'.",
+			Value: "frequency",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "The number of candidates and letters to show.",
+			Value: 10,
+		},
+	},
+	Action: hangmanAction,
+}
+
+func hangmanAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 || ctx.Args().First() == "" {
+		return fmt.Errorf("expected a single pattern, e.g. '_a__a_'")
+	}
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	strategy := ctx.String("strategy")
+	if strategy != "frequency" && strategy != "entropy" {
+		return fmt.Errorf("invalid strategy %q; expected 'frequency' or 'entropy'", strategy)
+	}
+	pattern := []rune(normalizeLetters(ctx.Args().First()))
+	wrong := []rune(strings.ToLower(ctx.String("wrong")))
+
+	candidates := hangmanCandidates(sortedWords(dict), pattern, wrong)
+	limit := ctx.Int("limit")
+	fmt.Printf("%d candidates\n", len(candidates))
+	for index, candidate := range candidates {
+		if limit > 0 && index >= limit {
+			fmt.Println("...")
+			break
+		}
+		fmt.Println(candidate)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	letters := rankHangmanLetters(candidates, pattern, wrong, strategy)
+	fmt.Printf("\nletters by %s:\n", strategy)
+	for index, letter := range letters {
+		if limit > 0 && index >= limit {
+			break
+		}
+		fmt.Printf("%s (%s)\n", string(letter.Letter), formatScore(letter.Score))
+	}
+	return nil
+}
+
+// hangmanCandidates returns the words matching a revealed pattern.
+//
+// as hangman reveals every copy of a letter at once, the hidden
+// positions can't hold any letter that's already been revealed.
+func hangmanCandidates(words []string, pattern, wrong []rune) []string {
+	revealed := make(Set[rune])
+	for _, r := range pattern {
+		if r != MASK_CHAR {
+			revealed.Add(r)
+		}
+	}
+	var output []string
+	for _, word := range words {
+		runes := []rune(word)
+		if !greenMatches(pattern, runes) || !grayMatches(wrong, runes) {
+			continue
+		}
+		valid := true
+		for index, r := range runes {
+			if pattern[index] == MASK_CHAR && revealed.Has(r) {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			output = append(output, word)
+		}
+	}
+	return output
+}
+
+// hangmanLetter is a letter to guess and its score.
+type hangmanLetter struct {
+	Letter rune
+	Score  float64
+}
+
+// rankHangmanLetters ranks the letters not yet guessed, either by the
+// number of candidates containing the letter ("frequency") or by the
+// information the positions it would reveal gives ("entropy").
+func rankHangmanLetters(candidates []string, pattern, wrong []rune, strategy string) []hangmanLetter {
+	guessed := NewSet(wrong)
+	for _, r := range pattern {
+		guessed.Add(r)
+	}
+	letters := make(Set[rune])
+	for _, candidate := range candidates {
+		for _, r := range candidate {
+			if !guessed.Has(r) {
+				letters.Add(r)
+			}
+		}
+	}
+
+	var output []hangmanLetter
+	for letter := range letters {
+		var score float64
+		if strategy == "entropy" {
+			counts := make(map[string]int)
+			for _, candidate := range candidates {
+				var positions strings.Builder
+				for index, r := range []rune(candidate) {
+					if r == letter {
+						fmt.Fprintf(&positions, "%d,", index)
+					}
+				}
+				counts[positions.String()]++
+			}
+			score = entropy(counts)
+		} else {
+			for _, candidate := range candidates {
+				if strings.ContainsRune(candidate, letter) {
+					score++
+				}
+			}
+		}
+		output = append(output, hangmanLetter{Letter: letter, Score: score})
+	}
+	sort.Slice(output, func(i, j int) bool {
+		if output[i].Score != output[j].Score {
+			return output[i].Score > output[j].Score
+		}
+		return output[i].Letter < output[j].Letter
+	})
+	return output
+}
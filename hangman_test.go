@@ -0,0 +1,30 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_hangmanCandidates(t *testing.T) {
+	words := []string{"banal", "canal", "nasal", "llama", "naval", "salsa", "abaca"}
+
+	matched := hangmanCandidates(words, []rune("_a_a_"), []rune("s"))
+	if strings.Join(matched, ",") != "banal,canal,naval" {
+		t.Fatalf("expect revealed letters to not appear in hidden positions, got %v", matched)
+	}
+}
+
+func Test_rankHangmanLetters(t *testing.T) {
+	candidates := []string{"banal", "canal", "naval"}
+
+	letters := rankHangmanLetters(candidates, []rune("_a_a_"), nil, "frequency")
+	if letters[0].Letter != 'l' || letters[0].Score != 3 {
+		t.Fatalf("expect l to be in every candidate, got %v", letters[0])
+	}
+
+	candidates = []string{"banal", "canal", "naval", "natal"}
+	letters = rankHangmanLetters(candidates, []rune("_a_al"), nil, "entropy")
+	if letters[0].Letter != 'n' {
+		t.Fatalf("expect n to split the candidates best, got %v", string(letters[0].Letter))
+	}
+}
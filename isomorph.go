@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+var isomorphCommand = &cli.Command{
+	Name:      "isomorph",
+	Usage:     "find words with the same letter repetition shape as a pattern or cipher word (e.g. 'ABCCA' or 'xqzzx')",
+	ArgsUsage: "[shape]",
+	Flags: []cli.Flag{
+		dictFlag(),
+		&cli.StringSliceFlag{
+			Name:  "known",
+			Usage: "A known mapping from a cipher symbol, of this or another word of the puzzle, to a letter in 'symbol=letter' form, e.g. 'x=s' (can be multiple!)",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "If we should limit the number of results shown.",
+		},
+	},
+	Action: isomorphAction,
+}
+
+func isomorphAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("expected a single shape, e.g. 'ABCCA'")
+	}
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	known, err := parseKnownMappings(ctx.StringSlice("known"))
+	if err != nil {
+		return err
+	}
+	matched, err := findIsomorphs(sortedWords(dict), []rune(ctx.Args().First()), known)
+	if err != nil {
+		return err
+	}
+	limit := ctx.Int("limit")
+	for index, word := range matched {
+		if limit > 0 && index >= limit {
+			break
+		}
+		fmt.Println(word)
+	}
+	return nil
+}
+
+// parseKnownMappings parses mappings in "symbol=letter" form.
+func parseKnownMappings(inputs []string) (map[rune]rune, error) {
+	output := make(map[rune]rune)
+	for _, input := range inputs {
+		symbol, letter, ok := strings.Cut(input, "=")
+		symbolRunes, letterRunes := []rune(symbol), []rune(strings.ToLower(letter))
+		if !ok || len(symbolRunes) != 1 || len(letterRunes) != 1 {
+			return nil, fmt.Errorf("invalid mapping %q; expected the form 'symbol=letter'", input)
+		}
+		output[symbolRunes[0]] = letterRunes[0]
+	}
+	return output, nil
+}
+
+// isomorphShape returns the shape of a word, where each position is
+// given as the index of the first position holding the same symbol.
+//
+// e.g. both "ABCCA" and "xqzzx" have the shape [0 1 2 2 0].
+func isomorphShape(word []rune) []int {
+	output := make([]int, len(word))
+	first := make(map[rune]int)
+	for index, r := range word {
+		if firstIndex, ok := first[r]; ok {
+			output[index] = firstIndex
+			continue
+		}
+		first[r] = index
+		output[index] = index
+	}
+	return output
+}
+
+// findIsomorphs returns the words with the same shape as the symbols,
+// where distinct symbols always map to distinct letters.
+//
+// known mappings are applied as a green mask over the symbol positions;
+// matching the shape then keeps the other symbols off the known letters.
+// known symbols that don't appear in the word (e.g. from other words of a
+// cryptogram) still take their letters, so no other symbol can be one.
+func findIsomorphs(words []string, symbols []rune, known map[rune]rune) ([]string, error) {
+	takenLetters := make(map[rune]rune)
+	for symbol, letter := range known {
+		if other, ok := takenLetters[letter]; ok {
+			return nil, fmt.Errorf("symbols %q and %q can't both map to %q", other, symbol, letter)
+		}
+		takenLetters[letter] = symbol
+	}
+	green := make([]rune, len(symbols))
+	for index, symbol := range symbols {
+		green[index] = MASK_CHAR
+		if letter, ok := known[symbol]; ok {
+			green[index] = letter
+		}
+	}
+	shape := isomorphShape(symbols)
+
+	var output []string
+	for _, word := range words {
+		runes := []rune(word)
+		if greenMatches(green, runes) && shapesEqual(shape, isomorphShape(runes)) && !usesTakenLetter(green, runes, takenLetters) {
+			output = append(output, word)
+		}
+	}
+	return output, nil
+}
+
+// usesTakenLetter returns if an unknown symbol of the word would have
+// to be a letter already taken by a known symbol.
+func usesTakenLetter(green, word []rune, takenLetters map[rune]rune) bool {
+	for index, r := range word {
+		if green[index] != MASK_CHAR {
+			continue
+		}
+		if _, ok := takenLetters[r]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func shapesEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for index := range a {
+		if a[index] != b[index] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_findIsomorphs(t *testing.T) {
+	words := []string{"sells", "stems", "eerie", "tweet", "seeds", "shoos", "abbey"}
+
+	matched, err := findIsomorphs(words, []rune("ABCCA"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(matched, ",") != "sells,tweet,shoos" {
+		t.Fatalf("expect words shaped ABCCA, got %v", matched)
+	}
+
+	matched, err = findIsomorphs(words, []rune("xqzzx"), map[rune]rune{'q': 'h'})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(matched, ",") != "shoos" {
+		t.Fatalf("expect known mappings to apply, got %v", matched)
+	}
+
+	// k comes from another word of the puzzle, so no symbol here can be s.
+	matched, err = findIsomorphs(words, []rune("xqzzx"), map[rune]rune{'k': 's'})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(matched, ",") != "tweet" {
+		t.Fatalf("expect letters known from other words to be ruled out, got %v", matched)
+	}
+
+	if _, err := findIsomorphs(words, []rune("xqzzx"), map[rune]rune{'x': 's', 'q': 's'}); err == nil {
+		t.Fatalf("expect two symbols mapped to the same letter to error")
+	}
+}
+
+func Test_parseKnownMappings(t *testing.T) {
+	known, err := parseKnownMappings([]string{"x=S"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if known['x'] != 's' {
+		t.Fatalf("expect x to map to s, got %v", known)
+	}
+	if _, err := parseKnownMappings([]string{"xs"}); err == nil {
+		t.Fatalf("expect a mapping without '=' to error")
+	}
+}
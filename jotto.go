@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+var jottoCommand = &cli.Command{
+	Name:  "jotto",
+	Usage: "solve jotto style games, where feedback is only the number of letters in common",
+	Flags: []cli.Flag{
+		dictFlag(),
+		answersFlag(),
+		&cli.StringSliceFlag{
+			Name:  "guess",
+			Usage: "A guess and its common letter count in 'word:count' form, e.g. 'crane:2' (can be multiple!)",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "The number of candidates and suggestions to show.",
+			Value: 10,
+		},
+	},
+	Action: jottoAction,
+}
+
+func jottoAction(ctx *cli.Context) error {
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	answers, err := getAnswers(ctx.String("answers"), dict)
+	if err != nil {
+		return err
+	}
+	var rows []jottoRow
+	for _, guess := range ctx.StringSlice("guess") {
+		row, err := parseJottoRow(guess)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+
+	candidates := jottoCandidates(answers, rows)
+	limit := ctx.Int("limit")
+	fmt.Printf("%d candidates\n", len(candidates))
+	for index, candidate := range candidates {
+		if limit > 0 && index >= limit {
+			fmt.Println("...")
+			break
+		}
+		fmt.Println(candidate)
+	}
+	if len(candidates) <= 1 {
+		return nil
+	}
+
+	guesses := sortedWords(dict)
+	ranked := rankGuesses(guesses, nil, jottoScorer(candidates), newProgress("ranking guesses", len(guesses)))
+	fmt.Println("\nsuggestions:")
+	printRanked(ranked, limit)
+	return nil
+}
+
+// jottoRow is a guess and the number of letters it shares with the secret.
+type jottoRow struct {
+	Word   string
+	Common int
+}
+
+// parseJottoRow parses a row in "word:count" form.
+func parseJottoRow(input string) (jottoRow, error) {
+	word, count, ok := strings.Cut(input, ":")
+	if !ok {
+		return jottoRow{}, fmt.Errorf("invalid guess %q; expected the form 'word:count'", input)
+	}
+	common, err := strconv.Atoi(count)
+	if err != nil {
+		return jottoRow{}, fmt.Errorf("invalid count in guess %q: %w", input, err)
+	}
+	return jottoRow{Word: strings.ToLower(word), Common: common}, nil
+}
+
+// runeCountsCommon returns the number of letters a and b share,
+// counting repeated letters as many times as they appear in both.
+func runeCountsCommon(a, b map[rune]int) int {
+	var output int
+	for key, aCount := range a {
+		if bCount := b[key]; bCount < aCount {
+			output += bCount
+		} else {
+			output += aCount
+		}
+	}
+	return output
+}
+
+// jottoCandidates returns the answers consistent with every row.
+func jottoCandidates(answers []string, rows []jottoRow) []string {
+	rowCounts := make([]map[rune]int, len(rows))
+	for index, row := range rows {
+		rowCounts[index] = runeCounts(row.Word)
+	}
+	var output []string
+	for _, answer := range answers {
+		answerCounts := runeCounts(answer)
+		valid := true
+		for index, row := range rows {
+			if runeCountsCommon(rowCounts[index], answerCounts) != row.Common {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			output = append(output, answer)
+		}
+	}
+	return output
+}
+
+// jottoScorer returns a scorer giving the expected information in bits
+// of a guess when the feedback is only the common letter count.
+//
+// as anagrams always get the same feedback, the candidates are grouped
+// by their letter counts once up front (kept densely by letter index so
+// the hot loop avoids map lookups), and the returned scorer ignores the
+// candidates it's passed.
+func jottoScorer(candidates []string) scorer {
+	type letterClass struct {
+		Counts []int
+		Size   int
+	}
+	alphabet := make(map[rune]int)
+	for _, candidate := range candidates {
+		for _, r := range candidate {
+			if _, ok := alphabet[r]; !ok {
+				alphabet[r] = len(alphabet)
+			}
+		}
+	}
+	var classes []*letterClass
+	byKey := make(map[string]*letterClass)
+	for _, candidate := range candidates {
+		key := sortedLetters(candidate)
+		class, ok := byKey[key]
+		if !ok {
+			class = &letterClass{Counts: make([]int, len(alphabet))}
+			for r, count := range runeCounts(candidate) {
+				class.Counts[alphabet[r]] = count
+			}
+			byKey[key] = class
+			classes = append(classes, class)
+		}
+		class.Size++
+	}
+	return func(guess []rune, _ [][]rune) float64 {
+		type letterCount struct {
+			Index, Count int
+		}
+		var guessCounts []letterCount
+		for r, count := range runeCounts(string(guess)) {
+			if index, ok := alphabet[r]; ok {
+				guessCounts = append(guessCounts, letterCount{index, count})
+			}
+		}
+		counts := make(map[int]int)
+		for _, class := range classes {
+			var common int
+			for _, gc := range guessCounts {
+				if c := class.Counts[gc.Index]; c < gc.Count {
+					common += c
+				} else {
+					common += gc.Count
+				}
+			}
+			counts[common] += class.Size
+		}
+		return entropy(counts)
+	}
+}
+
+// sortedLetters returns the letters of a word in sorted order, which
+// is the same for every anagram of the word.
+func sortedLetters(word string) string {
+	runes := []rune(word)
+	sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+	return string(runes)
+}
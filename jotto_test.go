@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_runeCountsCommon(t *testing.T) {
+	if common := runeCountsCommon(runeCounts("lolly"), runeCounts("hello")); common != 3 {
+		t.Fatalf("expect lolly and hello to share 3 letters, got %d", common)
+	}
+}
+
+func Test_jottoCandidates(t *testing.T) {
+	answers := []string{"crane", "slosh", "dumpy", "stare", "lotsa"}
+	rows := []jottoRow{
+		{Word: "tares", Common: 5},
+	}
+	if matched := strings.Join(jottoCandidates(answers, rows), ","); matched != "stare" {
+		t.Fatalf("expect only the anagram to match, got %s", matched)
+	}
+
+	row, err := parseJottoRow("SLOTH:4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matched := strings.Join(jottoCandidates(answers, []jottoRow{row}), ","); matched != "slosh,lotsa" {
+		t.Fatalf("expect words sharing 4 letters with sloth, got %s", matched)
+	}
+	if _, err := parseJottoRow("sloth"); err == nil {
+		t.Fatalf("expect a row without a count to error")
+	}
+}
+
+func Test_jottoScorer(t *testing.T) {
+	candidates := []string{"crane", "slosh", "dumpy"}
+	score := jottoScorer(candidates)
+	if score([]rune("zzzzz"), nil) != 0 {
+		t.Fatalf("expect a guess sharing no letters to give no information")
+	}
+	if score([]rune("cramp"), nil) <= score([]rune("crane"), nil) {
+		t.Fatalf("expect cramp to split the candidates better than crane")
+	}
+}
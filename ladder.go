@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+var ladderCommand = &cli.Command{
+	Name:      "ladder",
+	Usage:     "find the shortest word ladder between two words, changing a letter at a time",
+	ArgsUsage: "[from] [to]",
+	Flags: []cli.Flag{
+		dictFlag(),
+		&cli.BoolFlag{
+			Name:  "all",
+			Usage: "If we should list every shortest ladder instead of just the first.",
+		},
+		&cli.BoolFlag{
+			Name:  "indels",
+			Usage: "If steps may also add or remove a letter.",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "If we should limit the number of ladders shown.",
+		},
+	},
+	Action: ladderAction,
+}
+
+func ladderAction(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("expected a word to start from and a word to end at")
+	}
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	from, to := strings.ToLower(ctx.Args().Get(0)), strings.ToLower(ctx.Args().Get(1))
+	for _, word := range []string{from, to} {
+		if !dict.Has(word) {
+			return fmt.Errorf("%q is not in the dictionary", word)
+		}
+	}
+	indels := ctx.Bool("indels")
+	if !indels && len([]rune(from)) != len([]rune(to)) {
+		return fmt.Errorf("%q and %q must be the same length unless --indels is set", from, to)
+	}
+
+	index := newLadderIndex(sortedWords(dict), indels)
+	ladders := index.Shortest(from, to, ctx.Bool("all"))
+	if len(ladders) == 0 {
+		return fmt.Errorf("no ladder found from %q to %q", from, to)
+	}
+	limit := ctx.Int("limit")
+	for ladderIndex, ladder := range ladders {
+		if limit > 0 && ladderIndex >= limit {
+			break
+		}
+		fmt.Printf("%s (%d steps)\n", strings.Join(ladder, " -> "), len(ladder)-1)
+	}
+	return nil
+}
+
+// newLadderIndex builds the neighbour index for the given words.
+//
+// words are bucketed by each of their wildcard forms (e.g. "cold" under
+// "_old", "c_ld", "co_d" and "col_"), so the words one letter change
+// away are the other words sharing a bucket. With indels, words are also
+// bucketed by each form with a letter removed, so the words one letter
+// longer can be found with a single lookup.
+func newLadderIndex(words []string, indels bool) *ladderIndex {
+	li := &ladderIndex{
+		words:     NewSet(words),
+		wildcards: make(map[string][]string),
+		indels:    indels,
+	}
+	if indels {
+		li.deletions = make(map[string][]string)
+	}
+	for _, word := range words {
+		runes := []rune(word)
+		for position := range runes {
+			key := replaceAt(runes, position, MASK_CHAR)
+			li.wildcards[key] = append(li.wildcards[key], word)
+			if indels {
+				deleted := removeAt(runes, position)
+				li.deletions[deleted] = appendUnique(li.deletions[deleted], word)
+			}
+		}
+	}
+	return li
+}
+
+// ladderIndex finds the neighbours of words for word ladders.
+type ladderIndex struct {
+	words     Set[string]
+	wildcards map[string][]string
+	deletions map[string][]string
+	indels    bool
+}
+
+// Neighbors returns the words a single step away from a word.
+func (li *ladderIndex) Neighbors(word string) []string {
+	var output []string
+	seen := make(Set[string])
+	add := func(neighbor string) {
+		if neighbor != word && !seen.Has(neighbor) {
+			seen.Add(neighbor)
+			output = append(output, neighbor)
+		}
+	}
+	runes := []rune(word)
+	for position := range runes {
+		for _, neighbor := range li.wildcards[replaceAt(runes, position, MASK_CHAR)] {
+			add(neighbor)
+		}
+	}
+	if li.indels {
+		for position := range runes {
+			if deleted := removeAt(runes, position); li.words.Has(deleted) {
+				add(deleted)
+			}
+		}
+		for _, neighbor := range li.deletions[word] {
+			add(neighbor)
+		}
+	}
+	return output
+}
+
+// Shortest returns the shortest ladders from one word to another with
+// a breadth first search, either just the first found or all of them.
+func (li *ladderIndex) Shortest(from, to string, all bool) [][]string {
+	parents := map[string][]string{from: nil}
+	frontier := []string{from}
+	for len(frontier) > 0 && !hasKey(parents, to) {
+		var next []string
+		levelParents := make(map[string][]string)
+		for _, word := range frontier {
+			for _, neighbor := range li.Neighbors(word) {
+				if hasKey(parents, neighbor) {
+					continue
+				}
+				if _, ok := levelParents[neighbor]; !ok {
+					next = append(next, neighbor)
+				}
+				levelParents[neighbor] = append(levelParents[neighbor], word)
+			}
+		}
+		for word, wordParents := range levelParents {
+			parents[word] = wordParents
+		}
+		frontier = next
+	}
+	if !hasKey(parents, to) {
+		return nil
+	}
+
+	var output [][]string
+	path := []string{to}
+	var walk func(word string) bool
+	walk = func(word string) bool {
+		if word == from {
+			ladder := make([]string, len(path))
+			for index := range path {
+				ladder[index] = path[len(path)-1-index]
+			}
+			output = append(output, ladder)
+			return !all
+		}
+		for _, parent := range parents[word] {
+			path = append(path, parent)
+			done := walk(parent)
+			path = path[:len(path)-1]
+			if done {
+				return true
+			}
+		}
+		return false
+	}
+	walk(to)
+	return output
+}
+
+func hasKey[K comparable, V any](m map[K]V, key K) bool {
+	_, ok := m[key]
+	return ok
+}
+
+func replaceAt(runes []rune, position int, r rune) string {
+	output := append([]rune(nil), runes...)
+	output[position] = r
+	return string(output)
+}
+
+func removeAt(runes []rune, position int) string {
+	return string(runes[:position]) + string(runes[position+1:])
+}
+
+func appendUnique(values []string, value string) []string {
+	if len(values) > 0 && values[len(values)-1] == value {
+		return values
+	}
+	return append(values, value)
+}
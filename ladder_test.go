@@ -0,0 +1,39 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_ladderIndex_Shortest(t *testing.T) {
+	words := []string{"bold", "bolt", "card", "cold", "cord", "corm", "old", "ward", "warm", "worm"}
+
+	index := newLadderIndex(words, false)
+	ladders := index.Shortest("cold", "warm", true)
+	if len(ladders) != 2 {
+		t.Fatalf("expect 2 shortest ladders, got %v", ladders)
+	}
+	if strings.Join(ladders[0], " ") != "cold cord card ward warm" {
+		t.Fatalf("unexpected ladder %v", ladders[0])
+	}
+	if ladders := index.Shortest("cold", "warm", false); len(ladders) != 1 {
+		t.Fatalf("expect a single ladder without all, got %v", ladders)
+	}
+	if ladders := index.Shortest("old", "bolt", false); len(ladders) != 0 {
+		t.Fatalf("expect no ladder between lengths without indels, got %v", ladders)
+	}
+}
+
+func Test_ladderIndex_indels(t *testing.T) {
+	words := []string{"bold", "bolt", "cold", "old"}
+
+	index := newLadderIndex(words, true)
+	neighbors := strings.Join(index.Neighbors("old"), ",")
+	if neighbors != "bold,cold" {
+		t.Fatalf("expect old to neighbor the words one letter longer, got %s", neighbors)
+	}
+	ladders := index.Shortest("bolt", "old", false)
+	if len(ladders) != 1 || strings.Join(ladders[0], " ") != "bolt bold old" {
+		t.Fatalf("unexpected ladders %v", ladders)
+	}
+}
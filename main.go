@@ -6,13 +6,18 @@ import (
 	_ "embed"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/urfave/cli/v2"
 )
 
+// dictionary is the default word list; its weights are built by
+// gen_dictionary.go, which documents how to rebuild them.
+//
 //go:embed dictionary.txt
 var dictionary []byte
 
@@ -28,7 +33,7 @@ var app = &cli.App{
 	Flags: []cli.Flag{
 		&cli.StringFlag{
 			Name:  "dict",
-			Usage: "The dictionary path (optional, will use embedded dictionary by default)",
+			Usage: "The dictionary path, one word per line optionally followed by a tab and a weight (optional, will use embedded dictionary by default)",
 		},
 		&cli.StringFlag{
 			Name:  "green",
@@ -51,9 +56,63 @@ var app = &cli.App{
 			Aliases: []string{"m"},
 			Usage:   "If we should show match results.",
 		},
+		&cli.StringSliceFlag{
+			Name:  "guess",
+			Usage: "A guess and its feedback in 'word:feedback' form, e.g. 'crane:gyxxx' (can be multiple!)",
+		},
+		&cli.StringFlag{
+			Name:  "state",
+			Usage: "The path of a state file to load constraints from and save them to (optional)",
+		},
+		priorFlag("frequency"),
+	},
+	Commands: []*cli.Command{
+		openersCommand,
+		absurdleCommand,
+		multiCommand,
+		playCommand,
+		shareCommand,
+		analyzeCommand,
+		anagramCommand,
+		phraseCommand,
+		beeCommand,
+		boxedCommand,
+		hangmanCommand,
+		ladderCommand,
+		jottoCommand,
+		gridCommand,
+		crosswordCommand,
+		isomorphCommand,
+		scrabbleCommand,
+		waffleCommand,
+		fibbleCommand,
+		xordleCommand,
+		nerdleCommand,
 	},
 }
 
+func dictFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "dict",
+		Usage: "The dictionary path, one word per line optionally followed by a tab and a weight (optional, will use embedded dictionary by default)",
+	}
+}
+
+func answersFlag() cli.Flag {
+	return &cli.StringFlag{
+		Name:  "answers",
+		Usage: "The possible answers path (optional, will use the dictionary by default)",
+	}
+}
+
+func scorerFlag(defaultScorer string) cli.Flag {
+	return &cli.StringFlag{
+		Name:  "scorer",
+		Usage: "The scorer used to rank words, one of 'heuristic', 'entropy', 'frequency' or 'expected' (entropy weighed by the prior)",
+		Value: defaultScorer,
+	}
+}
+
 func main() {
 	err := app.Run(os.Args)
 	if err != nil {
@@ -63,15 +122,21 @@ func main() {
 }
 
 func action(ctx *cli.Context) error {
-	dict, err := getDictionary(ctx.String("dict"))
+	dict, weights, err := readDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+
+	p, err := getPrior(ctx.String("prior"), weights)
 	if err != nil {
 		return err
 	}
 
 	flagLimit := ctx.Int("limit")
-	yellows := ctx.StringSlice("yellow")
-	green := []rune(ctx.String("green"))
-	gray := []rune(ctx.String("gray"))
+	green, yellows, gray, err := resolveConstraints(ctx)
+	if err != nil {
+		return err
+	}
 
 	var isDebug = os.Getenv("DEBUG") != ""
 	debugf := func(format string, args ...any) {
@@ -98,14 +163,30 @@ func action(ctx *cli.Context) error {
 			}
 			matched = append(matched, wordWithScore{
 				Word:  dictWord,
-				Score: scoreWordMatch(dictWord),
+				Score: float64(scoreWordMatch(dictWord, invertedScrabbleWeights)),
 			})
 		}
+		matchedWords := make([]string, len(matched))
+		for index, ws := range matched {
+			matchedWords[index] = ws.Word
+		}
+		probabilities := make(map[string]float64, len(matched))
+		for index, probability := range posteriors(matchedWords, p) {
+			probabilities[matchedWords[index]] = probability
+		}
+		// the likeliest answers are listed first, falling back to the
+		// heuristic score.
 		sort.SliceStable(matched, func(i, j int) bool {
-			return matched[i].Score > matched[j].Score
+			if iProbability, jProbability := probabilities[matched[i].Word], probabilities[matched[j].Word]; iProbability != jProbability {
+				return iProbability > jProbability
+			}
+			if matched[i].Score != matched[j].Score {
+				return matched[i].Score > matched[j].Score
+			}
+			return matched[i].Word < matched[j].Word
 		})
 		for index, ws := range matched {
-			fmt.Printf("%s (%d)\n", ws.Word, ws.Score)
+			fmt.Printf("%s (%s) %.2f%%\n", ws.Word, formatScore(ws.Score), 100*probabilities[ws.Word])
 			if flagLimit > 0 && index > flagLimit {
 				break
 			}
@@ -130,7 +211,7 @@ func action(ctx *cli.Context) error {
 			}
 			discover = append(discover, wordWithScore{
 				Word:  dictWord,
-				Score: scoreWordMatch(dictWord),
+				Score: float64(scoreWordMatch(dictWord, invertedScrabbleWeights)),
 			})
 		}
 		sort.SliceStable(discover, func(i, j int) bool {
@@ -138,7 +219,7 @@ func action(ctx *cli.Context) error {
 		})
 		if len(discover) > 0 {
 			for index, ws := range discover {
-				fmt.Printf("%s (%d)\n", ws.Word, ws.Score)
+				fmt.Printf("%s (%s)\n", ws.Word, formatScore(ws.Score))
 				if flagLimit > 0 && index > flagLimit {
 					break
 				}
@@ -151,24 +232,98 @@ func action(ctx *cli.Context) error {
 
 type wordWithScore struct {
 	Word  string
-	Score int
+	Score float64
 }
 
 func getDictionary(dictPath string) (Set[string], error) {
+	output, _, err := readDictionary(dictPath)
+	return output, err
+}
+
+// readDictionary reads a dictionary of one word per line, where each word
+// may be followed by a tab and its weight (e.g. how often it's used).
+//
+// blank lines and lines starting with '#' are skipped.
+func readDictionary(dictPath string) (Set[string], wordWeights, error) {
 	r, err := getDictionaryReader(dictPath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer r.Close()
 
-	output := make(Set[string])
+	words := make(Set[string])
+	weights := make(wordWeights)
 	scanner := bufio.NewScanner(r)
+	var lineNumber int
 	for scanner.Scan() {
-		output.Add(scanner.Text())
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word, weight, hasWeight := strings.Cut(line, "\t")
+		word = strings.TrimSpace(word)
+		words.Add(word)
+		if !hasWeight {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+		if err != nil || parsed < 0 {
+			return nil, nil, fmt.Errorf("invalid weight %q for %q on line %d", weight, word, lineNumber)
+		}
+		weights[word] = parsed
 	}
+	return words, weights, scanner.Err()
+}
+
+// wordWeights are the relative weights of words, e.g. how often each is used.
+type wordWeights map[string]float64
+
+// Weight returns the weight of a word, where words without a weight weigh 1.
+func (ww wordWeights) Weight(word string) float64 {
+	if weight, ok := ww[word]; ok {
+		return weight
+	}
+	return 1
+}
+
+// getAnswers returns the possible answers in sorted order, which
+// are the dictionary words unless an answers path is given.
+func getAnswers(answersPath string, dict Set[string]) ([]string, error) {
+	output, _, err := readAnswers(answersPath, dict, nil)
 	return output, err
 }
 
+// readAnswers returns the possible answers as getAnswers does, along with
+// the dictionary weights overridden by any weights in the answers file.
+func readAnswers(answersPath string, dict Set[string], weights wordWeights) ([]string, wordWeights, error) {
+	if answersPath == "" {
+		return sortedWords(dict), weights, nil
+	}
+	answers, answerWeights, err := readDictionary(answersPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	merged := make(wordWeights, len(weights)+len(answerWeights))
+	for word, weight := range weights {
+		merged[word] = weight
+	}
+	for word, weight := range answerWeights {
+		merged[word] = weight
+	}
+	return sortedWords(answers), merged, nil
+}
+
+// sortedWords returns the words of a set in sorted order.
+func sortedWords(words Set[string]) []string {
+	output := make([]string, 0, len(words))
+	for word := range words {
+		output = append(output, word)
+	}
+	sort.Strings(output)
+	return output
+}
+
 func getDictionaryReader(dictPath string) (io.ReadCloser, error) {
 	if dictPath != "" {
 		dictFile, err := os.Open(dictPath)
@@ -312,25 +467,41 @@ scrabble score is given as:
 10 points – Q  Z
 */
 
-func scoreWordInvertedScrabble(c rune) int {
-	switch c {
-	case 'a', 'e', 'i', 'o', 'u', 'l', 'n', 's', 't', 'r':
-		return 10
-	case 'd', 'g':
-		return 8
-	case 'b', 'c', 'm', 'p':
-		return 5
-	case 'f', 'h', 'v', 'w', 'y':
-		return 4
-	case 'k':
-		return 3
-	case 'j', 'x':
-		return 2
-	case 'q', 'z':
-		return 1
-	default:
-		panic("invalid letter for inverted scrabble scoring")
+// letterWeights are the per letter weights used by scoreWordMatch;
+// letters without a weight count for nothing.
+type letterWeights map[rune]int
+
+// invertedScrabbleWeights weighs the letters a-z by inverting their
+// scrabble scores, so common letters weigh the most.
+var invertedScrabbleWeights = letterWeights{
+	'a': 10, 'e': 10, 'i': 10, 'o': 10, 'u': 10, 'l': 10, 'n': 10, 's': 10, 't': 10, 'r': 10,
+	'd': 8, 'g': 8,
+	'b': 5, 'c': 5, 'm': 5, 'p': 5,
+	'f': 4, 'h': 4, 'v': 4, 'w': 4, 'y': 4,
+	'k': 3,
+	'j': 2, 'x': 2,
+	'q': 1, 'z': 1,
+}
+
+// frequencyWeights derives weights for any alphabet from a word list, on
+// the same 1 to 10 scale as invertedScrabbleWeights, by the share of words
+// each symbol appears in relative to the most common symbol.
+func frequencyWeights(words []string) letterWeights {
+	counts := make(map[rune]int)
+	var most int
+	for _, word := range words {
+		for r := range runeCounts(word) {
+			counts[r]++
+			if counts[r] > most {
+				most = counts[r]
+			}
+		}
+	}
+	output := make(letterWeights)
+	for r, count := range counts {
+		output[r] = 1 + int(math.Round(9*float64(count)/float64(most)))
 	}
+	return output
 }
 
 func scoreWordUnique(word string) int {
@@ -341,11 +512,11 @@ func scoreWordUnique(word string) int {
 	return len(s)
 }
 
-func scoreWordMatch(word string) int {
-	var invertedScrabbleScore int
+func scoreWordMatch(word string, weights letterWeights) int {
+	var weightScore int
 	for _, c := range word {
-		invertedScrabbleScore += scoreWordInvertedScrabble(c)
+		weightScore += weights[c]
 	}
 	uniqueScore := scoreWordUnique(word)
-	return (uniqueScore * 20) + invertedScrabbleScore
+	return (uniqueScore * 20) + weightScore
 }
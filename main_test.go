@@ -1,6 +1,10 @@
 package main
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func Test_yellowsMatchesAll(t *testing.T) {
 	yellows := []string{
@@ -29,3 +33,54 @@ func Test_yellowsMatchesAny(t *testing.T) {
 		}
 	}
 }
+
+func Test_frequencyWeights(t *testing.T) {
+	weights := frequencyWeights([]string{"aab", "ac"})
+	if weights['a'] != 10 || weights['b'] != 6 || weights['c'] != 6 {
+		t.Fatalf("expect weights scaled by the share of words, got %v", weights)
+	}
+	if score := scoreWordMatch("1+2=3", invertedScrabbleWeights); score != 100 {
+		t.Fatalf("expect symbols without a weight to count for nothing, got %d", score)
+	}
+}
+
+func Test_readDictionary(t *testing.T) {
+	dictPath := filepath.Join(t.TempDir(), "dictionary.txt")
+	contents := "# a comment\nabout\t120\naahed\t1\n\ncrane\n"
+	if err := os.WriteFile(dictPath, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	words, weights, err := readDictionary(dictPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(words) != 3 || !words.Has("about") || !words.Has("crane") {
+		t.Fatalf("expect the words without comments or weights, got %v", words)
+	}
+	if weights.Weight("about") != 120 || weights.Weight("aahed") != 1 || weights.Weight("crane") != 1 {
+		t.Fatalf("expect the given weights, defaulting to 1, got %v", weights)
+	}
+
+	if err := os.WriteFile(dictPath, []byte("about\tlots\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := readDictionary(dictPath); err == nil {
+		t.Fatalf("expect an invalid weight to error")
+	}
+}
+
+func Test_readDictionary_embedded(t *testing.T) {
+	words, weights, err := readDictionary("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !words.Has("about") || words.Has("#") {
+		t.Fatalf("expect the embedded words without the header")
+	}
+	if weights.Weight("about") <= weights.Weight("aahed") {
+		t.Fatalf("expect about to weigh more than aahed")
+	}
+	if weights.Weight("water") <= weights.Weight("trait") || weights.Weight("music") <= weights.Weight("macro") {
+		t.Fatalf("expect everyday words to weigh more than jargon")
+	}
+}
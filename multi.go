@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+var multiCommand = &cli.Command{
+	Name:  "multi",
+	Usage: "solve several boards from the same guesses (dordle, quordle, octordle)",
+	Flags: []cli.Flag{
+		dictFlag(),
+		answersFlag(),
+		&cli.StringSliceFlag{
+			Name:  "guess",
+			Usage: "The guesses played so far, in order (can be multiple!)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "board",
+			Usage: "The feedback for one board, one '/' separated row per guess, e.g. 'gyxxx/xxggg' (can be multiple!)",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "The number of candidates to show for each board.",
+			Value: 10,
+		},
+	},
+	Action: multiAction,
+}
+
+func multiAction(ctx *cli.Context) error {
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	answers, err := getAnswers(ctx.String("answers"), dict)
+	if err != nil {
+		return err
+	}
+	boards, err := parseBoards(ctx.StringSlice("guess"), ctx.StringSlice("board"))
+	if err != nil {
+		return err
+	}
+	if len(boards) == 0 {
+		return fmt.Errorf("at least one --board is required")
+	}
+
+	candidates := wordRunes(answers)
+	remaining := make([][][]rune, len(boards))
+	limit := ctx.Int("limit")
+	for index, b := range boards {
+		if b.Solved() {
+			fmt.Printf("board %d: solved (%s)\n", index+1, b.Guesses[len(b.Guesses)-1])
+			continue
+		}
+		remaining[index] = b.Filter(candidates)
+		green, yellows, gray := b.Constraints()
+		fmt.Printf("board %d: %d candidates (--green %q --yellow %q --gray %q)\n", index+1, len(remaining[index]), string(green), strings.Join(yellows, ","), string(gray))
+		for candidateIndex, candidate := range remaining[index] {
+			if limit > 0 && candidateIndex >= limit {
+				fmt.Printf("  ...\n")
+				break
+			}
+			fmt.Printf("  %s\n", string(candidate))
+		}
+	}
+
+	guess, info, ok := suggestMultiGuess(wordRunes(sortedWords(dict)), remaining)
+	if !ok {
+		return nil
+	}
+	fmt.Printf("suggestion: %s (%s bits)\n", guess, formatScore(info))
+	return nil
+}
+
+// parseBoards builds a board per `--board` flag from the shared guesses.
+//
+// a board may have fewer feedback rows than there are guesses only if
+// its last row solved it.
+func parseBoards(guesses []string, boardFlags []string) ([]board, error) {
+	var output []board
+	for _, boardFlag := range boardFlags {
+		var b board
+		rows := strings.Split(boardFlag, "/")
+		if len(rows) > len(guesses) {
+			return nil, fmt.Errorf("board %q has more feedback rows than there are guesses", boardFlag)
+		}
+		for index, row := range rows {
+			feedback, err := parseFeedback(strings.TrimSpace(row))
+			if err != nil {
+				return nil, err
+			}
+			if err := b.Add(guesses[index], feedback); err != nil {
+				return nil, err
+			}
+		}
+		if len(rows) < len(guesses) && !b.Solved() {
+			return nil, fmt.Errorf("board %q is missing feedback rows but isn't solved", boardFlag)
+		}
+		output = append(output, b)
+	}
+	return output, nil
+}
+
+// suggestMultiGuess returns the guess with the most combined information
+// across the unsolved boards, given the candidates left on each board.
+//
+// if any board is down to a single candidate, that candidate is played
+// instead so the board is finished, picking whichever finisher tells us
+// the most about the other boards.
+func suggestMultiGuess(guesses [][]rune, remaining [][][]rune) (string, float64, bool) {
+	var open [][][]rune
+	var finishers [][]rune
+	for _, candidates := range remaining {
+		if len(candidates) == 0 {
+			continue
+		}
+		open = append(open, candidates)
+		if len(candidates) == 1 {
+			finishers = append(finishers, candidates[0])
+		}
+	}
+	if len(open) == 0 {
+		return "", 0, false
+	}
+	if len(finishers) > 0 {
+		guesses = finishers
+	}
+
+	combined := func(guess []rune, _ [][]rune) float64 {
+		var output float64
+		for _, candidates := range open {
+			output += scoreEntropy(guess, candidates)
+		}
+		return output
+	}
+	words := make([]string, len(guesses))
+	for index, guess := range guesses {
+		words[index] = string(guess)
+	}
+	ranked := rankGuesses(words, nil, combined, newProgress("ranking guesses", len(words)))
+	return ranked[0].Word, ranked[0].Score, true
+}
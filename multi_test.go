@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func Test_parseBoards(t *testing.T) {
+	guesses := []string{"crane", "slosh"}
+	if _, err := parseBoards(guesses, []string{"ggggg", "xxxxx/xxxxx"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseBoards(guesses, []string{"xxxxx"}); err == nil {
+		t.Fatalf("expect an unsolved board missing rows to error")
+	}
+}
+
+func Test_suggestMultiGuess(t *testing.T) {
+	guesses := wordRunes([]string{"crane", "slosh", "dumpy"})
+
+	guess, _, ok := suggestMultiGuess(guesses, [][][]rune{
+		nil,
+		wordRunes([]string{"dumpy"}),
+		wordRunes([]string{"crane", "slosh"}),
+	})
+	if !ok {
+		t.Fatalf("expect a suggestion")
+	}
+	if guess != "dumpy" {
+		t.Fatalf("expect the board with one candidate to be finished, got %s", guess)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+)
+
+// nerdleOperators are the operators nerdle equations may use.
+const nerdleOperators = "+-*/"
+
+var nerdleCommand = &cli.Command{
+	Name:  "nerdle",
+	Usage: "solve nerdle, where the words are generated arithmetic equations",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "length",
+			Usage: "The length of the equations.",
+			Value: 8,
+		},
+		&cli.StringSliceFlag{
+			Name:  "guess",
+			Usage: "A guess and its feedback in 'equation:feedback' form, e.g. '9*8-7=65:xgyxxgyx' (can be multiple!)",
+		},
+		scorerFlag("heuristic"),
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "The number of candidates and suggestions to show.",
+			Value: 10,
+		},
+	},
+	Action: nerdleAction,
+}
+
+func nerdleAction(ctx *cli.Context) error {
+	// there are no weights for equations, so every equation is as likely.
+	newScore, err := getScorer(ctx.String("scorer"), priorUniform)
+	if err != nil {
+		return err
+	}
+	var state solverState
+	for _, guess := range ctx.StringSlice("guess") {
+		if err := state.AddGuess(guess); err != nil {
+			return err
+		}
+	}
+	b, err := state.Board()
+	if err != nil {
+		return err
+	}
+
+	equations := generateNerdleEquations(ctx.Int("length"))
+	if len(equations) == 0 {
+		return fmt.Errorf("no equations of length %d", ctx.Int("length"))
+	}
+
+	candidates := b.Filter(wordRunes(equations))
+	score := newScore(candidates)
+	// the letter heuristic is reweighted for the symbols of the equations.
+	if scorerName(ctx.String("scorer")) == "heuristic" {
+		score = heuristicScorer(frequencyWeights(equations))
+	}
+
+	limit := ctx.Int("limit")
+	fmt.Printf("%d candidates\n", len(candidates))
+	for index, candidate := range candidates {
+		if limit > 0 && index >= limit {
+			fmt.Println("...")
+			break
+		}
+		fmt.Println(string(candidate))
+	}
+	if len(candidates) <= 1 {
+		return nil
+	}
+
+	ranked := rankGuesses(equations, candidates, score, newProgress("ranking guesses", len(equations)))
+	fmt.Println("\nsuggestions:")
+	printRanked(ranked, limit)
+	return nil
+}
+
+// generateNerdleEquations returns, sorted, every valid equation of the
+// given length.
+//
+// an equation is an expression of numbers and at least one operator, an
+// '=', and a non-negative whole number; numbers in the expression can't
+// start with a zero (so no lone zeros either), the expression is evaluated
+// exactly with the usual precedence, and a division by zero or a result
+// that isn't whole rules the equation out.
+func generateNerdleEquations(length int) []string {
+	var output []string
+	expression := make([]byte, 0, length)
+	var numbers []int64
+	var operators []byte
+
+	var walk func()
+	walk = func() {
+		if len(operators) > 0 && !isNerdleOperator(expression[len(expression)-1]) {
+			if value, ok := evaluateNerdle(numbers, operators); ok {
+				result := strconv.FormatInt(value, 10)
+				if len(expression)+1+len(result) == length {
+					output = append(output, string(expression)+"="+result)
+				}
+			}
+		}
+		// the '=' and at least one digit of the result still need to fit.
+		if len(expression)+2 >= length {
+			return
+		}
+		last := byte(0)
+		if len(expression) > 0 {
+			last = expression[len(expression)-1]
+		}
+		switch {
+		case last == 0 || isNerdleOperator(last):
+			for digit := byte('1'); digit <= '9'; digit++ {
+				expression = append(expression, digit)
+				numbers = append(numbers, int64(digit-'0'))
+				walk()
+				numbers = numbers[:len(numbers)-1]
+				expression = expression[:len(expression)-1]
+			}
+		default:
+			current := numbers[len(numbers)-1]
+			for digit := byte('0'); digit <= '9'; digit++ {
+				expression = append(expression, digit)
+				numbers[len(numbers)-1] = current*10 + int64(digit-'0')
+				walk()
+				expression = expression[:len(expression)-1]
+			}
+			numbers[len(numbers)-1] = current
+			for index := range nerdleOperators {
+				expression = append(expression, nerdleOperators[index])
+				operators = append(operators, nerdleOperators[index])
+				walk()
+				operators = operators[:len(operators)-1]
+				expression = expression[:len(expression)-1]
+			}
+		}
+	}
+	walk()
+	sort.Strings(output)
+	return output
+}
+
+func isNerdleOperator(c byte) bool {
+	for index := range nerdleOperators {
+		if nerdleOperators[index] == c {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateNerdle evaluates numbers joined by operators as an exact
+// fraction, returning the result if it's a non-negative whole number.
+func evaluateNerdle(numbers []int64, operators []byte) (int64, bool) {
+	// the sum so far and the current term, as numerator over denominator.
+	var sumNum, sumDen int64 = 0, 1
+	termNum, termDen := numbers[0], int64(1)
+	termSign := int64(1)
+	for index, operator := range operators {
+		number := numbers[index+1]
+		switch operator {
+		case '*':
+			termNum *= number
+		case '/':
+			if number == 0 {
+				return 0, false
+			}
+			termDen *= number
+		default:
+			sumNum, sumDen = sumNum*termDen+termSign*termNum*sumDen, sumDen*termDen
+			sumNum, sumDen = reduceFraction(sumNum, sumDen)
+			termNum, termDen = number, 1
+			termSign = 1
+			if operator == '-' {
+				termSign = -1
+			}
+			continue
+		}
+		termNum, termDen = reduceFraction(termNum, termDen)
+	}
+	sumNum, sumDen = sumNum*termDen+termSign*termNum*sumDen, sumDen*termDen
+	if sumNum < 0 || sumNum%sumDen != 0 {
+		return 0, false
+	}
+	return sumNum / sumDen, true
+}
+
+func reduceFraction(num, den int64) (int64, int64) {
+	a, b := num, den
+	if a < 0 {
+		a = -a
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	if a == 0 {
+		return 0, 1
+	}
+	return num / a, den / a
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_generateNerdleEquations(t *testing.T) {
+	equations := NewSet(generateNerdleEquations(5))
+	for _, equation := range []string{"1+2=3", "8/4=2", "9-9=0", "3*3=9"} {
+		if !equations.Has(equation) {
+			t.Fatalf("expect %q to be generated", equation)
+		}
+	}
+	for _, equation := range []string{"0+1=1", "3/2=1", "1-2=1", "12=12"} {
+		if equations.Has(equation) {
+			t.Fatalf("expect %q to not be generated", equation)
+		}
+	}
+	if count := len(generateNerdleEquations(8)); count != 17723 {
+		t.Fatalf("expect the 17723 classic nerdle equations, got %d", count)
+	}
+}
+
+func Test_evaluateNerdle(t *testing.T) {
+	testCases := [...]struct {
+		Numbers   []int64
+		Operators string
+		Expected  int64
+		OK        bool
+	}{
+		{[]int64{1, 2, 3}, "+*", 7, true},
+		{[]int64{8, 4, 6}, "/*", 12, true},
+		{[]int64{7, 2, 2}, "/*", 7, true},
+		{[]int64{1, 3, 3}, "/+", 0, false},
+		{[]int64{2, 3}, "-", 0, false},
+		{[]int64{2, 0}, "/", 0, false},
+	}
+	for _, tc := range testCases {
+		value, ok := evaluateNerdle(tc.Numbers, []byte(tc.Operators))
+		if ok != tc.OK || value != tc.Expected {
+			t.Fatalf("expect %v %q to be %d (%v), got %d (%v)", tc.Numbers, tc.Operators, tc.Expected, tc.OK, value, ok)
+		}
+	}
+}
+
+func Test_nerdleFeedback(t *testing.T) {
+	equations := wordRunes(generateNerdleEquations(8))
+	var b board
+	if err := b.Add("9*8-7=65", computeFeedback([]rune("9*8-7=65"), []rune("12+35=47"))); err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, candidate := range b.Filter(equations) {
+		if string(candidate) == "12+35=47" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expect the answer to remain a candidate")
+	}
+}
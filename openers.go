@@ -0,0 +1,367 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/urfave/cli/v2"
+)
+
+var openersCommand = &cli.Command{
+	Name:  "openers",
+	Usage: "rank opening words, and the best opening pairs and triples",
+	Flags: []cli.Flag{
+		dictFlag(),
+		answersFlag(),
+		scorerFlag("heuristic"),
+		priorFlag("frequency"),
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "The number of results to show for each section.",
+			Value: 10,
+		},
+		&cli.IntFlag{
+			Name:  "size",
+			Usage: "The largest combination of opening words to search (1, 2 or 3).",
+			Value: 3,
+		},
+		&cli.IntFlag{
+			Name:  "pool",
+			Usage: "The number of best combinations of each size kept to search larger combinations from.",
+			Value: 30,
+		},
+		&cli.IntFlag{
+			Name:  "sample",
+			Usage: "The most candidates combinations are shortlisted against by information; larger candidate lists are sampled evenly.",
+			Value: 1000,
+		},
+		&cli.StringFlag{
+			Name:  "objective",
+			Usage: "What combinations maximize, one of 'information' or 'coverage'.",
+			Value: "information",
+		},
+	},
+	Action: openersAction,
+}
+
+func openersAction(ctx *cli.Context) error {
+	dict, weights, err := readDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	answers, weights, err := readAnswers(ctx.String("answers"), dict, weights)
+	if err != nil {
+		return err
+	}
+	p, err := getPrior(ctx.String("prior"), weights)
+	if err != nil {
+		return err
+	}
+	newScore, err := getScorer(ctx.String("scorer"), p)
+	if err != nil {
+		return err
+	}
+	objective := ctx.String("objective")
+	if objective != "information" && objective != "coverage" {
+		return fmt.Errorf("invalid objective %q; expected 'information' or 'coverage'", objective)
+	}
+
+	guesses := sortedWords(dict)
+	candidates := wordRunes(answers)
+	ranked := rankGuesses(guesses, candidates, newScore(candidates), newProgress("ranking openers", len(guesses)))
+
+	limit := ctx.Int("limit")
+	fmt.Printf("openers by %s:\n", scorerName(ctx.String("scorer")))
+	printRanked(ranked, limit)
+
+	maxSize := ctx.Int("size")
+	if maxSize < 2 {
+		return nil
+	}
+	if maxSize > 3 {
+		maxSize = 3
+	}
+	levels := searchOpenerCombos(guesses, candidates, maxSize, ctx.Int("pool"), ctx.Int("sample"), objective)
+	for size := 2; size <= len(levels); size++ {
+		fmt.Printf("\n%s by %s:\n", comboLabel(size), objective)
+		for index, combo := range levels[size-1] {
+			if limit > 0 && index >= limit {
+				break
+			}
+			fmt.Printf("%s (%s)\n", strings.Join(combo.Words, " "), formatScore(combo.Score))
+		}
+	}
+	return nil
+}
+
+// openerCombo is a combination of opening words and its joint score.
+type openerCombo struct {
+	Words    []string
+	Score    float64
+	Tiebreak float64
+
+	guesses []int
+	// letters is the letters the words cover, as a letterMask.
+	letters uint32
+	// classes is, for each candidate, which part of the joint feedback
+	// partition it falls in, and classCount is how many parts there are.
+	classes    []int32
+	classCount int
+}
+
+// searchOpenerCombos returns the best combinations of each size from 1
+// to maxSize, best first, where output[size-1] has the combinations of
+// that size.
+//
+// the search is a beam search over every guess; the `width` best
+// combinations of each size are extended by every other guess, and each
+// extension is scored jointly, so later words are picked by what they
+// add to the earlier ones rather than by their rank on their own.
+//
+// the "information" objective is the entropy of the joint feedback over
+// the candidates, the "coverage" objective is the number of distinct
+// letters used with the share of candidates containing each letter
+// breaking ties; combinations covering the same letters are kept once.
+// information is scored against an even spread of `sample` candidates to
+// shortlist the extensions, which are then scored against all of them.
+func searchOpenerCombos(guesses []string, candidates [][]rune, maxSize, width, sample int, objective string) [][]openerCombo {
+	if width <= 0 {
+		width = 1
+	}
+	guessRunes := wordRunes(guesses)
+	masks := make([]uint32, len(guesses))
+	for index, guess := range guessRunes {
+		masks[index] = letterMask(guess)
+	}
+	var letterShares [26]float64
+	for _, candidate := range candidates {
+		mask := letterMask(candidate)
+		for letter := range letterShares {
+			if mask&(1<<letter) != 0 {
+				letterShares[letter] += 1 / float64(len(candidates))
+			}
+		}
+	}
+	coverage := func(mask uint32) (float64, float64) {
+		var count, share float64
+		for letter := range letterShares {
+			if mask&(1<<letter) != 0 {
+				count++
+				share += letterShares[letter]
+			}
+		}
+		return count, share
+	}
+
+	// the empty combination puts every candidate in the one class.
+	beam := []openerCombo{{classes: make([]int32, len(candidates)), classCount: 1}}
+	sampled := sampleIndexes(len(candidates), sample)
+	var output [][]openerCombo
+	for size := 1; size <= maxSize && len(beam) > 0; size++ {
+		// best returns the n best extensions of the beam, leaving out
+		// words already in a combination and equivalent combinations.
+		best := func(extensions []openerExtension, n int) []openerExtension {
+			words := func(e openerExtension) string {
+				return strings.Join(beam[e.Combo].Words, " ") + " " + guesses[e.Guess]
+			}
+			sort.SliceStable(extensions, func(i, j int) bool {
+				if extensions[i].Score != extensions[j].Score {
+					return extensions[i].Score > extensions[j].Score
+				}
+				if extensions[i].Tiebreak != extensions[j].Tiebreak {
+					return extensions[i].Tiebreak > extensions[j].Tiebreak
+				}
+				return words(extensions[i]) < words(extensions[j])
+			})
+			var output []openerExtension
+			seen := make(map[string]struct{})
+			for _, e := range extensions {
+				if len(output) >= n {
+					break
+				}
+				prefix := beam[e.Combo]
+				if containsInt(prefix.guesses, e.Guess) {
+					continue
+				}
+				key := openerComboKey(prefix, e.Guess, masks[e.Guess], objective)
+				if _, ok := seen[key]; ok {
+					continue
+				}
+				seen[key] = struct{}{}
+				output = append(output, e)
+			}
+			return output
+		}
+
+		var extensions []openerExtension
+		if objective == "coverage" {
+			for comboIndex, combo := range beam {
+				for guess, mask := range masks {
+					score, tiebreak := coverage(combo.letters | mask)
+					extensions = append(extensions, openerExtension{comboIndex, guess, score, tiebreak})
+				}
+			}
+		} else {
+			scores := make([][]float64, len(guesses))
+			p := newProgress("searching "+comboLabel(size), len(guesses))
+			parallelFor(len(guesses), func(guess int) {
+				scores[guess] = openerEntropies(guessRunes[guess], candidates, sampled, beam)
+				p.Inc()
+			})
+			p.Done()
+			for comboIndex := range beam {
+				for guess := range guesses {
+					extensions = append(extensions, openerExtension{comboIndex, guess, scores[guess][comboIndex], 0})
+				}
+			}
+			// scores against a sample only shortlist the extensions,
+			// which are then scored against every candidate.
+			if len(sampled) < len(candidates) {
+				extensions = best(extensions, width*openerShortlist)
+				every := sampleIndexes(len(candidates), 0)
+				parallelFor(len(extensions), func(index int) {
+					e := &extensions[index]
+					e.Score = openerEntropies(guessRunes[e.Guess], candidates, every, beam[e.Combo:e.Combo+1])[0]
+				})
+			}
+		}
+
+		selected := best(extensions, width)
+		previous := beam
+		beam = nil
+		for _, e := range selected {
+			prefix := previous[e.Combo]
+			combo := openerCombo{
+				Words:    append(append([]string(nil), prefix.Words...), guesses[e.Guess]),
+				Score:    e.Score,
+				Tiebreak: e.Tiebreak,
+				guesses:  append(append([]int(nil), prefix.guesses...), e.Guess),
+				letters:  prefix.letters | masks[e.Guess],
+			}
+			if objective != "coverage" {
+				combo.classes, combo.classCount = splitOpenerClasses(prefix.classes, guessRunes[e.Guess], candidates)
+			}
+			beam = append(beam, combo)
+		}
+		output = append(output, beam)
+	}
+	return output
+}
+
+// openerShortlist is how many times the beam width of extensions scored
+// against a sample of the candidates are scored against all of them.
+const openerShortlist = 4
+
+// openerExtension is a combination in the beam extended by a guess.
+type openerExtension struct {
+	Combo, Guess    int
+	Score, Tiebreak float64
+}
+
+// sampleIndexes returns an even spread of at most `sample` indexes in
+// [0, n), or every index if sample isn't positive.
+func sampleIndexes(n, sample int) []int {
+	if sample <= 0 || sample > n {
+		sample = n
+	}
+	output := make([]int, sample)
+	for index := range output {
+		output[index] = index * n / sample
+	}
+	return output
+}
+
+// openerCounts are reused between calls to openerEntropies, as the joint
+// feedback can have millions of possible values.
+var openerCounts = sync.Pool{New: func() any { return new([]int32) }}
+
+// openerEntropies returns, for each combination, the entropy of the joint
+// feedback over the candidates at the given indexes if the guess were
+// added to it.
+func openerEntropies(guess []rune, candidates [][]rune, indexes []int, combos []openerCombo) []float64 {
+	codes := make([]int32, len(indexes))
+	size := 1
+	for range guess {
+		size *= 3
+	}
+	for index, candidate := range indexes {
+		codes[index] = int32(feedbackCode(guess, candidates[candidate]))
+	}
+	counts := openerCounts.Get().(*[]int32)
+	defer openerCounts.Put(counts)
+	var touched []int
+	output := make([]float64, len(combos))
+	for comboIndex, combo := range combos {
+		if needed := combo.classCount * size; len(*counts) < needed {
+			*counts = make([]int32, needed)
+		}
+		touched = touched[:0]
+		for index, code := range codes {
+			key := int(combo.classes[indexes[index]])*size + int(code)
+			if (*counts)[key] == 0 {
+				touched = append(touched, key)
+			}
+			(*counts)[key]++
+		}
+		// the entropy of the counts is log2(n) - sum(c*log2(c))/n.
+		total := float64(len(codes))
+		var sum float64
+		for _, key := range touched {
+			count := float64((*counts)[key])
+			sum += count * math.Log2(count)
+			(*counts)[key] = 0
+		}
+		if total > 0 {
+			output[comboIndex] = math.Log2(total) - sum/total
+		}
+	}
+	return output
+}
+
+// splitOpenerClasses splits each class of candidates by the feedback the
+// guess gives them, returning the new classes and how many there are.
+func splitOpenerClasses(classes []int32, guess []rune, candidates [][]rune) ([]int32, int) {
+	ids := make(map[[2]int32]int32)
+	output := make([]int32, len(candidates))
+	for index, candidate := range candidates {
+		key := [2]int32{classes[index], int32(feedbackCode(guess, candidate))}
+		id, ok := ids[key]
+		if !ok {
+			id = int32(len(ids))
+			ids[key] = id
+		}
+		output[index] = id
+	}
+	return output, len(ids)
+}
+
+// openerComboKey identifies equivalent combinations when a combination
+// is extended by a guess; for coverage that's the letters covered,
+// otherwise the set of words regardless of order.
+func openerComboKey(combo openerCombo, guess int, mask uint32, objective string) string {
+	if objective == "coverage" {
+		return fmt.Sprint(combo.letters | mask)
+	}
+	sorted := append([]int{guess}, combo.guesses...)
+	sort.Ints(sorted)
+	return fmt.Sprint(sorted)
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func comboLabel(size int) string {
+	if size == 2 {
+		return "pairs"
+	}
+	return "triples"
+}
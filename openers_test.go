@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_searchOpenerCombos_coverage(t *testing.T) {
+	guesses := []string{"aaaaa", "crane", "sloth", "crate"}
+	candidates := wordRunes([]string{"crane", "sloth", "dumpy"})
+
+	levels := searchOpenerCombos(guesses, candidates, 2, 10, 0, "coverage")
+	if len(levels) != 2 || len(levels[1]) != 6 {
+		t.Fatalf("expect 6 pairs, got %v", levels)
+	}
+	if best := strings.Join(levels[1][0].Words, " "); best != "crane sloth" {
+		t.Fatalf("expect best pair to be crane sloth, got %s", best)
+	}
+	if levels[1][0].Score != 10 {
+		t.Fatalf("expect best pair to cover 10 letters, got %v", levels[1][0].Score)
+	}
+}
+
+func Test_searchOpenerCombos_coverageBeam(t *testing.T) {
+	// the near anagrams rank above the words that complete them, so only
+	// picking later words by what they add finds the 15 letter triple.
+	guesses := []string{"aeons", "aeros", "arose", "stare", "bulky", "dwarf", "pinch", "glimp"}
+	candidates := wordRunes([]string{"arose", "stare", "aeons"})
+
+	levels := searchOpenerCombos(guesses, candidates, 3, 2, 0, "coverage")
+	if best := levels[2][0]; best.Score != 15 {
+		t.Fatalf("expect a triple covering 15 letters, got %v (%v)", best.Words, best.Score)
+	}
+}
+
+func Test_searchOpenerCombos_information(t *testing.T) {
+	guesses := []string{"aaaaa", "crane", "sloth"}
+	candidates := wordRunes([]string{"sloth", "dumpy", "fiver"})
+
+	levels := searchOpenerCombos(guesses, candidates, 2, 10, 0, "information")
+	pairs := levels[1]
+	if best := strings.Join(pairs[0].Words, " "); best != "crane sloth" && best != "sloth crane" {
+		t.Fatalf("expect best pair to be crane and sloth, got %s", best)
+	}
+	if pairs[0].Score <= pairs[1].Score {
+		t.Fatalf("expect crane and sloth to split the candidates better than pairs using aaaaa")
+	}
+	if len(pairs) != 3 {
+		t.Fatalf("expect each pair of words once regardless of order, got %v", pairs)
+	}
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/urfave/cli/v2"
+)
+
+var phraseCommand = &cli.Command{
+	Name:      "phrase",
+	Usage:     "find combinations of dictionary words that use up every letter of a phrase",
+	ArgsUsage: "[phrase]",
+	Flags: []cli.Flag{
+		dictFlag(),
+		&cli.IntFlag{
+			Name:  "max-words",
+			Usage: "The most words a result may have.",
+			Value: 3,
+		},
+		&cli.IntFlag{
+			Name:  "min-length",
+			Usage: "The shortest word a result may use.",
+			Value: 2,
+		},
+		&cli.StringSliceFlag{
+			Name:  "exclude",
+			Usage: "Words results must not use (can be multiple!)",
+		},
+		&cli.StringSliceFlag{
+			Name:  "require",
+			Usage: "Words every result must use (can be multiple!)",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "If we should limit the number of results shown.",
+		},
+	},
+	Action: phraseAction,
+}
+
+func phraseAction(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return fmt.Errorf("expected a phrase")
+	}
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	search := phraseSearch{
+		MaxWords:  ctx.Int("max-words"),
+		MinLength: ctx.Int("min-length"),
+		Exclude:   NewSet(ctx.StringSlice("exclude")),
+		Require:   ctx.StringSlice("require"),
+	}
+	results, err := search.Find(sortedWords(dict), strings.Join(ctx.Args().Slice(), " "))
+	if err != nil {
+		return err
+	}
+	limit := ctx.Int("limit")
+	for index, result := range results {
+		if limit > 0 && index >= limit {
+			break
+		}
+		fmt.Println(strings.Join(result, " "))
+	}
+	return nil
+}
+
+// phraseSearch finds phrase anagrams, that is combinations of words
+// whose letters exactly use up the letters of an input phrase.
+type phraseSearch struct {
+	MaxWords  int
+	MinLength int
+	Exclude   Set[string]
+	Require   []string
+}
+
+// Find returns every combination of words (ignoring order) that uses
+// up the letters of the phrase, fewest words first.
+//
+// the search backtracks over the words whose letter counts are within
+// the remaining letters, with the first word of each combination
+// searched in parallel.
+func (ps phraseSearch) Find(words []string, phrase string) ([][]string, error) {
+	var letters []rune
+	for _, c := range strings.ToLower(phrase) {
+		if unicode.IsLetter(c) {
+			letters = append(letters, c)
+		}
+	}
+	remaining := runeCounts(string(letters))
+	for _, required := range ps.Require {
+		requiredCounts := runeCounts(strings.ToLower(required))
+		if !runeCountsWithin(requiredCounts, remaining) {
+			return nil, fmt.Errorf("required word %q doesn't fit in the phrase %q", required, phrase)
+		}
+		for key, count := range requiredCounts {
+			remaining[key] -= count
+			if remaining[key] == 0 {
+				delete(remaining, key)
+			}
+		}
+	}
+	maxWords := ps.MaxWords - len(ps.Require)
+	if len(remaining) == 0 {
+		return [][]string{ps.withRequired(nil)}, nil
+	}
+	if maxWords <= 0 {
+		return nil, nil
+	}
+
+	// letter counts are kept as slices indexed by the phrase alphabet
+	// so they're cheap to copy and compare while backtracking.
+	var alphabet []rune
+	for key := range remaining {
+		alphabet = append(alphabet, key)
+	}
+	sort.Slice(alphabet, func(i, j int) bool { return alphabet[i] < alphabet[j] })
+	toCounts := func(counts map[rune]int) []int {
+		output := make([]int, len(alphabet))
+		for index, key := range alphabet {
+			output[index] = counts[key]
+		}
+		return output
+	}
+
+	var candidates []string
+	var candidateCounts [][]int
+	for _, word := range words {
+		if len([]rune(word)) < ps.MinLength || ps.Exclude.Has(word) {
+			continue
+		}
+		wordCounts := runeCounts(word)
+		if !runeCountsWithin(wordCounts, remaining) {
+			continue
+		}
+		candidates = append(candidates, word)
+		candidateCounts = append(candidateCounts, toCounts(wordCounts))
+	}
+
+	results := make([][][]string, len(candidates))
+	p := newProgress("searching phrases", len(candidates))
+	parallelFor(len(candidates), func(first int) {
+		defer p.Inc()
+		counts := toCounts(remaining)
+		path := []string{}
+		var walk func(start int)
+		walk = func(start int) {
+			empty := true
+			for _, count := range counts {
+				if count != 0 {
+					empty = false
+					break
+				}
+			}
+			if empty {
+				results[first] = append(results[first], ps.withRequired(path))
+				return
+			}
+			if len(path) >= maxWords {
+				return
+			}
+			for index := start; index < len(candidates); index++ {
+				if !countsWithin(candidateCounts[index], counts) {
+					continue
+				}
+				subtractCounts(counts, candidateCounts[index])
+				path = append(path, candidates[index])
+				walk(index)
+				path = path[:len(path)-1]
+				addCounts(counts, candidateCounts[index])
+			}
+		}
+		subtractCounts(counts, candidateCounts[first])
+		path = append(path, candidates[first])
+		walk(first)
+	})
+	p.Done()
+
+	var output [][]string
+	for _, result := range results {
+		output = append(output, result...)
+	}
+	sort.SliceStable(output, func(i, j int) bool {
+		return len(output[i]) < len(output[j])
+	})
+	return output, nil
+}
+
+func (ps phraseSearch) withRequired(path []string) []string {
+	return append(append([]string(nil), ps.Require...), path...)
+}
+
+func countsWithin(a, b []int) bool {
+	for index := range a {
+		if a[index] > b[index] {
+			return false
+		}
+	}
+	return true
+}
+
+func subtractCounts(a, b []int) {
+	for index := range b {
+		a[index] -= b[index]
+	}
+}
+
+func addCounts(a, b []int) {
+	for index := range b {
+		a[index] += b[index]
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_phraseSearch_Find(t *testing.T) {
+	words := []string{"dirt", "dirty", "dormitory", "moor", "room", "to", "y"}
+	search := phraseSearch{MaxWords: 3, MinLength: 1}
+
+	results, err := search.Find(words, "Dirty Room!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var joined []string
+	for _, result := range results {
+		joined = append(joined, strings.Join(result, " "))
+	}
+	expected := "dormitory|dirty moor|dirty room|dirt moor y|dirt room y"
+	if strings.Join(joined, "|") != expected {
+		t.Fatalf("expect %s, got %s", expected, strings.Join(joined, "|"))
+	}
+}
+
+func Test_phraseSearch_Find_requireExclude(t *testing.T) {
+	words := []string{"dirt", "dirty", "dormitory", "moor", "room", "to", "y"}
+	search := phraseSearch{
+		MaxWords:  3,
+		MinLength: 1,
+		Exclude:   NewSet([]string{"dirty"}),
+		Require:   []string{"room"},
+	}
+	results, err := search.Find(words, "dirty room")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || strings.Join(results[0], " ") != "room dirt y" {
+		t.Fatalf("expect only room dirt y, got %v", results)
+	}
+
+	search.Require = []string{"zebra"}
+	if _, err := search.Find(words, "dirty room"); err == nil {
+		t.Fatalf("expect a required word that doesn't fit to error")
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// wordleEpoch is the date of the first wordle puzzle, used to number
+// puzzles by date.
+var wordleEpoch = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
+
+var playCommand = &cli.Command{
+	Name:  "play",
+	Usage: "play a game of wordle in the terminal",
+	Flags: []cli.Flag{
+		dictFlag(),
+		answersFlag(),
+		&cli.Int64Flag{
+			Name:  "seed",
+			Usage: "The seed used to pick a random secret (optional, will pick the secret for the date by default)",
+		},
+		&cli.TimestampFlag{
+			Name:   "date",
+			Usage:  "The date of the puzzle to play (optional, will use today by default)",
+			Layout: "2006-01-02",
+		},
+		&cli.IntFlag{
+			Name:  "turns",
+			Usage: "The number of guesses allowed.",
+			Value: 6,
+		},
+		&cli.BoolFlag{
+			Name:  "hard",
+			Usage: "If revealed hints must be used in subsequent guesses.",
+		},
+	},
+	Action: playAction,
+}
+
+func playAction(ctx *cli.Context) error {
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	answers, err := getAnswers(ctx.String("answers"), dict)
+	if err != nil {
+		return err
+	}
+	if len(answers) == 0 {
+		return fmt.Errorf("the answer list is empty")
+	}
+
+	var secret, title string
+	if ctx.IsSet("seed") {
+		seed := ctx.Int64("seed")
+		secret = answers[rand.New(rand.NewSource(seed)).Intn(len(answers))]
+		title = fmt.Sprintf("ana seed %d", seed)
+	} else {
+		// today is the local calendar date, as the puzzle changes at
+		// local midnight.
+		now := time.Now()
+		date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if ctx.IsSet("date") {
+			date = *ctx.Timestamp("date")
+		}
+		secret = secretForDate(answers, date)
+		title = fmt.Sprintf("ana %s", date.Format("2006-01-02"))
+	}
+
+	g := &game{
+		Secret: []rune(secret),
+		Dict:   dict,
+		Turns:  ctx.Int("turns"),
+		Hard:   ctx.Bool("hard"),
+	}
+	return playGame(g, title, os.Stdin, os.Stdout)
+}
+
+// secretForDate deterministically picks the secret for a calendar date,
+// taking the year, month and day of the date in its own location.
+func secretForDate(answers []string, date time.Time) string {
+	year, month, day := date.Date()
+	days := int64(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Sub(wordleEpoch).Hours() / 24)
+	return answers[rand.New(rand.NewSource(days)).Intn(len(answers))]
+}
+
+// playGame reads guesses a line at a time until the game is over,
+// then prints the share grid.
+func playGame(g *game, title string, input io.Reader, output io.Writer) error {
+	scanner := bufio.NewScanner(input)
+	fmt.Fprintf(output, "guess the %d letter word in %d turns\n", len(g.Secret), g.Turns)
+	for !g.Over() && scanner.Scan() {
+		guess := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if guess == "" {
+			continue
+		}
+		feedback, err := g.Guess(guess)
+		if err != nil {
+			fmt.Fprintln(output, err.Error())
+			continue
+		}
+		fmt.Fprintf(output, "%s %d/%d\n", renderFeedback([]rune(guess), feedback), len(g.Guesses), g.Turns)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if !g.Won() {
+		fmt.Fprintf(output, "the word was %s\n", string(g.Secret))
+	}
+	fmt.Fprintf(output, "\n%s\n", g.Share(title))
+	return nil
+}
+
+// game is a single game of wordle against a known secret.
+type game struct {
+	Secret []rune
+	Dict   Set[string]
+	Turns  int
+	Hard   bool
+
+	Guesses  []string
+	Feedback [][]byte
+}
+
+// Guess validates and plays a guess, returning its feedback.
+func (g *game) Guess(word string) ([]byte, error) {
+	if g.Over() {
+		return nil, fmt.Errorf("the game is over")
+	}
+	guessRunes := []rune(word)
+	if len(guessRunes) != len(g.Secret) {
+		return nil, fmt.Errorf("%q must be %d letters", word, len(g.Secret))
+	}
+	if !g.Dict.Has(word) {
+		return nil, fmt.Errorf("%q is not in the dictionary", word)
+	}
+	if g.Hard {
+		for index, previous := range g.Guesses {
+			if err := hardModeCheck([]rune(previous), g.Feedback[index], guessRunes); err != nil {
+				return nil, err
+			}
+		}
+	}
+	feedback := computeFeedback(guessRunes, g.Secret)
+	g.Guesses = append(g.Guesses, word)
+	g.Feedback = append(g.Feedback, feedback)
+	return feedback, nil
+}
+
+// Won returns if the last guess was all greens.
+func (g *game) Won() bool {
+	return len(g.Feedback) > 0 && allGreen(g.Feedback[len(g.Feedback)-1])
+}
+
+// Over returns if the game was won or the turns are used up.
+func (g *game) Over() bool {
+	return g.Won() || len(g.Guesses) >= g.Turns
+}
+
+// Share returns the shareable emoji grid for the game.
+func (g *game) Share(title string) string {
+	score := "X"
+	if g.Won() {
+		score = fmt.Sprint(len(g.Guesses))
+	}
+	var hard string
+	if g.Hard {
+		hard = "*"
+	}
+	lines := []string{fmt.Sprintf("%s %s/%d%s", title, score, g.Turns, hard)}
+	for _, feedback := range g.Feedback {
+		lines = append(lines, shareEmoji(feedback))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// shareEmoji renders feedback as a row of (dark theme) emoji squares.
+func shareEmoji(feedback []byte) string {
+	var sb strings.Builder
+	for _, f := range feedback {
+		switch f {
+		case feedbackGreen:
+			sb.WriteString("🟩")
+		case feedbackYellow:
+			sb.WriteString("🟨")
+		default:
+			sb.WriteString("⬛")
+		}
+	}
+	return sb.String()
+}
+
+// hardModeCheck returns an error if a guess doesn't reuse the hints
+// revealed by a previous guess; greens must stay in place and yellows
+// must appear somewhere in the guess.
+func hardModeCheck(previous []rune, feedback []byte, guess []rune) error {
+	required := make(map[rune]int)
+	for index, r := range previous {
+		switch feedback[index] {
+		case feedbackGreen:
+			if guess[index] != r {
+				return fmt.Errorf("letter %d must be %q", index+1, strings.ToUpper(string(r)))
+			}
+			required[r]++
+		case feedbackYellow:
+			required[r]++
+		}
+	}
+	guessCounts := runeCounts(string(guess))
+	for _, r := range previous {
+		if guessCounts[r] < required[r] {
+			return fmt.Errorf("guess must contain %q", strings.ToUpper(string(r)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_game_hardMode(t *testing.T) {
+	g := &game{
+		Secret: []rune("abide"),
+		Dict:   NewSet([]string{"speed", "crane", "aside", "abide"}),
+		Turns:  6,
+		Hard:   true,
+	}
+	if _, err := g.Guess("speed"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Guess("crane"); err == nil {
+		t.Fatalf("expect a guess missing revealed letters to be rejected in hard mode")
+	}
+	if _, err := g.Guess("aside"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Guess("abide"); err != nil {
+		t.Fatal(err)
+	}
+	if !g.Won() || !g.Over() {
+		t.Fatalf("expect the game to be won")
+	}
+}
+
+func Test_playGame(t *testing.T) {
+	g := &game{
+		Secret: []rune("abide"),
+		Dict:   NewSet([]string{"speed", "abide"}),
+		Turns:  6,
+	}
+	output := new(bytes.Buffer)
+	if err := playGame(g, "ana", strings.NewReader("zzzzz\nspeed\nabide\n"), output); err != nil {
+		t.Fatal(err)
+	}
+	expected := "ana 2/6\n⬛⬛🟨⬛🟨\n🟩🟩🟩🟩🟩"
+	if !strings.Contains(output.String(), expected) {
+		t.Fatalf("expect the share grid %q, got %q", expected, output.String())
+	}
+}
+
+func Test_secretForDate(t *testing.T) {
+	answers := []string{"abide", "crane", "slosh", "dumpy"}
+	date := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	if secretForDate(answers, date) != secretForDate(answers, date.Add(time.Hour)) {
+		t.Fatalf("expect the secret to be the same for the whole day")
+	}
+	local := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.FixedZone("UTC+10", 10*60*60))
+	if secretForDate(answers, date) != secretForDate(answers, local) {
+		t.Fatalf("expect the secret to follow the calendar date in the date's location")
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// prior gives the relative likelihood, before any feedback, that a
+// word is the answer; it needn't be normalised.
+type prior func(word string) float64
+
+// priorNames are the priors that can be chosen with `--prior`.
+var priorNames = []string{"frequency", "heuristic", "uniform"}
+
+func priorFlag(defaultPrior string) cli.Flag {
+	return &cli.StringFlag{
+		Name:  "prior",
+		Usage: "How likely each word is to be the answer, one of 'uniform', 'frequency' (by the dictionary weights) or 'heuristic'",
+		Value: defaultPrior,
+	}
+}
+
+// getPrior returns a prior by name, defaulting to the uniform prior.
+func getPrior(name string, weights wordWeights) (prior, error) {
+	switch strings.ToLower(name) {
+	case "", "uniform":
+		return priorUniform, nil
+	case "frequency":
+		return frequencyPrior(weights), nil
+	case "heuristic":
+		return priorHeuristic, nil
+	default:
+		return nil, fmt.Errorf("invalid prior %q; expected one of %s", name, strings.Join(priorNames, ", "))
+	}
+}
+
+func priorUniform(_ string) float64 {
+	return 1
+}
+
+// frequencyPrior weighs words by the log of their dictionary weight.
+//
+// usage counts span several orders of magnitude, and answers tend to be
+// drawn from the common words rather than in proportion to their usage,
+// so the log keeps the most used words from drowning out the rest.
+func frequencyPrior(weights wordWeights) prior {
+	return func(word string) float64 {
+		return math.Log1p(weights.Weight(word))
+	}
+}
+
+// priorHeuristic is a rough answer likelihood model; words made of common,
+// distinct letters are likelier, and words that look like plurals or past
+// tenses (which answer lists tend to leave out) are much less likely.
+func priorHeuristic(word string) float64 {
+	output := float64(scoreWordMatch(word, invertedScrabbleWeights))
+	switch {
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		output *= 0.1
+	case strings.HasSuffix(word, "ed"):
+		output *= 0.2
+	}
+	return output
+}
+
+// posteriors returns the probability of each word being the answer under
+// the prior, normalised over the words; the words are taken to be the
+// ones consistent with the feedback so far.
+func posteriors(words []string, p prior) []float64 {
+	output := make([]float64, len(words))
+	var total float64
+	for index, word := range words {
+		output[index] = p(word)
+		total += output[index]
+	}
+	for index := range output {
+		if total > 0 {
+			output[index] /= total
+		} else {
+			output[index] = 1 / float64(len(words))
+		}
+	}
+	return output
+}
+
+// weightedEntropy returns the entropy in bits of the given masses,
+// summed in sorted order so the result is deterministic.
+func weightedEntropy[K comparable](masses map[K]float64) float64 {
+	var total float64
+	sizes := make([]float64, 0, len(masses))
+	for _, mass := range masses {
+		total += mass
+		sizes = append(sizes, mass)
+	}
+	if total == 0 {
+		return 0
+	}
+	sort.Float64s(sizes)
+	var output float64
+	for _, mass := range sizes {
+		if mass > 0 {
+			p := mass / total
+			output -= p * math.Log2(p)
+		}
+	}
+	return output
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_posteriors(t *testing.T) {
+	probabilities := posteriors([]string{"about", "aahed", "crane"}, frequencyPrior(wordWeights{"about": 99}))
+	var total float64
+	for _, probability := range probabilities {
+		total += probability
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Fatalf("expect the probabilities to sum to 1, got %v", total)
+	}
+	if probabilities[0] <= probabilities[1] || probabilities[1] != probabilities[2] {
+		t.Fatalf("expect the weighted word to be likeliest and the rest equal, got %v", probabilities)
+	}
+
+	probabilities = posteriors([]string{"about", "aahed"}, priorUniform)
+	if probabilities[0] != 0.5 || probabilities[1] != 0.5 {
+		t.Fatalf("expect a uniform prior to split evenly, got %v", probabilities)
+	}
+}
+
+func Test_getPrior(t *testing.T) {
+	if _, err := getPrior("bogus", nil); err == nil {
+		t.Fatalf("expect an unknown prior to error")
+	}
+	p, err := getPrior("heuristic", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p("stare") <= p("tares") || p("stare") <= p("rated") {
+		t.Fatalf("expect plurals and past tenses to be less likely")
+	}
+}
+
+func Test_expectedScorer(t *testing.T) {
+	candidates := wordRunes([]string{"aa", "bb"})
+
+	uniform := expectedScorer(priorUniform, candidates)
+	if score := uniform([]rune("aa"), candidates); math.Abs(score-1.5) > 1e-9 {
+		t.Fatalf("expect 1 bit plus half a bit for the chance of winning, got %v", score)
+	}
+	if score := uniform([]rune("ab"), candidates); math.Abs(score-1) > 1e-9 {
+		t.Fatalf("expect 1 bit for a guess that can't win, got %v", score)
+	}
+
+	likely := expectedScorer(func(word string) float64 {
+		if word == "bb" {
+			return 3
+		}
+		return 1
+	}, candidates)
+	if likely([]rune("bb"), candidates) <= likely([]rune("aa"), candidates) {
+		t.Fatalf("expect the likelier answer to score higher")
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// newProgress returns a progress indicator that writes to stderr,
+// or nil if stderr isn't a terminal.
+//
+// a nil progress is valid and does nothing.
+func newProgress(label string, total int) *progress {
+	info, err := os.Stderr.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return nil
+	}
+	return &progress{
+		output: os.Stderr,
+		label:  label,
+		total:  total,
+	}
+}
+
+// progress prints a single updating line of "done / total" progress.
+type progress struct {
+	mu     sync.Mutex
+	output io.Writer
+	label  string
+	total  int
+	done   int
+	last   time.Time
+}
+
+// Inc marks a single unit of work as complete.
+func (p *progress) Inc() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	if now := time.Now(); now.Sub(p.last) > 100*time.Millisecond || p.done == p.total {
+		p.last = now
+		fmt.Fprintf(p.output, "\r%s %d/%d (%d%%)", p.label, p.done, p.total, (100*p.done)/p.total)
+	}
+}
+
+// Done clears the progress line.
+func (p *progress) Done() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprint(p.output, "\r\033[K")
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// scorer scores a guess given the candidate answers that remain; higher is better.
+type scorer func(guess []rune, candidates [][]rune) float64
+
+// newScorer returns a scorer for the given candidates; scorers that
+// weigh the candidates up front are built once per set of candidates.
+type newScorer func(candidates [][]rune) scorer
+
+// scorers are the named scorers that can be chosen with `--scorer`, given
+// the prior for the scorers that weigh how likely each candidate is.
+var scorers = map[string]func(p prior, candidates [][]rune) scorer{
+	"heuristic": func(prior, [][]rune) scorer { return scoreHeuristic },
+	"entropy":   func(prior, [][]rune) scorer { return scoreEntropy },
+	"frequency": func(prior, [][]rune) scorer { return scoreFrequency },
+	"expected":  expectedScorer,
+}
+
+// getScorer returns a scorer by name, defaulting to the heuristic scorer;
+// a nil prior is taken as uniform.
+func getScorer(name string, p prior) (newScorer, error) {
+	if name == "" {
+		name = "heuristic"
+	}
+	build, ok := scorers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("invalid scorer %q; expected one of %s", name, strings.Join(scorerNames(), ", "))
+	}
+	if p == nil {
+		p = priorUniform
+	}
+	return func(candidates [][]rune) scorer {
+		return build(p, candidates)
+	}, nil
+}
+
+// scorerName returns the name of the scorer that getScorer would use.
+func scorerName(name string) string {
+	if name == "" {
+		return "heuristic"
+	}
+	return strings.ToLower(name)
+}
+
+func scorerNames() []string {
+	var output []string
+	for name := range scorers {
+		output = append(output, name)
+	}
+	sort.Strings(output)
+	return output
+}
+
+// scoreHeuristic is the original letter heuristic, ignoring the candidates.
+var scoreHeuristic = heuristicScorer(invertedScrabbleWeights)
+
+// heuristicScorer returns the letter heuristic scorer for a given set of
+// letter weights, e.g. for alphabets other than a-z.
+func heuristicScorer(weights letterWeights) scorer {
+	return func(guess []rune, _ [][]rune) float64 {
+		return float64(scoreWordMatch(string(guess), weights))
+	}
+}
+
+// scoreEntropy returns the expected information in bits the guess
+// would reveal about the candidates.
+func scoreEntropy(guess []rune, candidates [][]rune) float64 {
+	return entropy(partitionCounts(guess, candidates))
+}
+
+// expectedScorer returns a scorer giving the information in bits a guess
+// is expected to reveal with the candidates weighted by the prior, plus,
+// for the chance the guess is itself the answer, the bits left to find;
+// so late in a game a likely answer can win out over a more informative
+// guess that can't win.
+//
+// the prior of each candidate is looked up once, up front, so the
+// candidates the scorer is passed are ignored.
+func expectedScorer(p prior, candidates [][]rune) scorer {
+	weights := make([]float64, len(candidates))
+	masses := make(map[int]float64, len(candidates))
+	var total float64
+	for index, candidate := range candidates {
+		weights[index] = p(string(candidate))
+		masses[index] = weights[index]
+		total += weights[index]
+	}
+	remaining := weightedEntropy(masses)
+	return func(guess []rune, _ [][]rune) float64 {
+		if total == 0 {
+			return 0
+		}
+		masses := make(map[int]float64)
+		for index, candidate := range candidates {
+			masses[feedbackCode(guess, candidate)] += weights[index]
+		}
+		solved := masses[feedbackCode(guess, guess)] / total
+		return weightedEntropy(masses) + solved*remaining
+	}
+}
+
+// scoreFrequency sums, for each distinct letter of the guess, the
+// fraction of candidates that contain that letter.
+func scoreFrequency(guess []rune, candidates [][]rune) float64 {
+	if len(candidates) == 0 {
+		return 0
+	}
+	letters := make(Set[rune])
+	for _, r := range guess {
+		letters.Add(r)
+	}
+	var output float64
+	for letter := range letters {
+		var count int
+		for _, candidate := range candidates {
+			for _, r := range candidate {
+				if r == letter {
+					count++
+					break
+				}
+			}
+		}
+		output += float64(count) / float64(len(candidates))
+	}
+	return output
+}
+
+// rankGuesses scores every guess against the candidates in parallel,
+// returning the results sorted best first.
+//
+// ties are broken alphabetically so results are stable between runs.
+func rankGuesses(guesses []string, candidates [][]rune, score scorer, p *progress) []wordWithScore {
+	output := make([]wordWithScore, len(guesses))
+	parallelFor(len(guesses), func(index int) {
+		output[index] = wordWithScore{
+			Word:  guesses[index],
+			Score: score([]rune(guesses[index]), candidates),
+		}
+		p.Inc()
+	})
+	p.Done()
+	sortWordsWithScore(output)
+	return output
+}
+
+func sortWordsWithScore(words []wordWithScore) {
+	sort.SliceStable(words, func(i, j int) bool {
+		if words[i].Score != words[j].Score {
+			return words[i].Score > words[j].Score
+		}
+		return words[i].Word < words[j].Word
+	})
+}
+
+// formatScore prints whole scores as integers and everything else
+// to three decimal places.
+func formatScore(score float64) string {
+	if score == math.Trunc(score) {
+		return fmt.Sprintf("%d", int64(score))
+	}
+	return fmt.Sprintf("%.3f", score)
+}
+
+// parallelFor calls the action for each index in [0, count) spread
+// across one worker per cpu.
+func parallelFor(count int, action func(int)) {
+	workers := runtime.NumCPU()
+	if workers > count {
+		workers = count
+	}
+	work := make(chan int, workers)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for worker := 0; worker < workers; worker++ {
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				action(index)
+			}
+		}()
+	}
+	for index := 0; index < count; index++ {
+		work <- index
+	}
+	close(work)
+	wg.Wait()
+}
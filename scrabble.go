@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// scrabbleBingoBonus is awarded for playing every tile of a full rack.
+const scrabbleBingoBonus = 50
+
+// scrabbleRackSize is the number of tiles in a full rack.
+const scrabbleRackSize = 7
+
+var scrabbleCommand = &cli.Command{
+	Name:      "scrabble",
+	Usage:     "find the highest scoring words playable from a rack",
+	ArgsUsage: "[rack]",
+	Flags: []cli.Flag{
+		dictFlag(),
+		&cli.StringFlag{
+			Name:  "line",
+			Usage: "A board line as space separated cells; '.' for empty, 'DL', 'TL', 'DW' or 'TW' for premium squares, or a placed letter (e.g. '. DL . a . . TW')",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "If we should limit the number of results shown.",
+			Value: 10,
+		},
+	},
+	Action: scrabbleAction,
+}
+
+func scrabbleAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("expected a single rack of letters, with '_' or '?' for blanks")
+	}
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	rack := normalizeLetters(ctx.Args().First())
+	var line []scrabbleCell
+	if lineFlag := ctx.String("line"); lineFlag != "" {
+		if line, err = parseScrabbleLine(lineFlag); err != nil {
+			return err
+		}
+	}
+
+	plays := findScrabblePlays(sortedWords(dict), rack, line)
+	limit := ctx.Int("limit")
+	for index, play := range plays {
+		if limit > 0 && index >= limit {
+			break
+		}
+		if line != nil {
+			fmt.Printf("%s (%d) at %d\n", play.Word, play.Score, play.Start+1)
+			continue
+		}
+		fmt.Printf("%s (%d)\n", play.Word, play.Score)
+	}
+	return nil
+}
+
+// scrabbleCell is a single square of a board line.
+type scrabbleCell struct {
+	Letter     rune
+	LetterMult int
+	WordMult   int
+}
+
+// parseScrabbleLine parses a board line; see the `--line` flag.
+func parseScrabbleLine(input string) ([]scrabbleCell, error) {
+	var output []scrabbleCell
+	for _, token := range strings.Fields(input) {
+		cell := scrabbleCell{LetterMult: 1, WordMult: 1}
+		switch token {
+		case ".", string(MASK_CHAR):
+		case "DL":
+			cell.LetterMult = 2
+		case "TL":
+			cell.LetterMult = 3
+		case "DW":
+			cell.WordMult = 2
+		case "TW":
+			cell.WordMult = 3
+		default:
+			runes := []rune(strings.ToLower(token))
+			if len(runes) != 1 {
+				return nil, fmt.Errorf("invalid board cell %q", token)
+			}
+			cell.Letter = runes[0]
+		}
+		output = append(output, cell)
+	}
+	return output, nil
+}
+
+// scrabblePlay is a playable word, where it starts on the line, and its score.
+type scrabblePlay struct {
+	Word  string
+	Start int
+	Score int
+}
+
+// findScrabblePlays returns the playable words from a rack, best first.
+//
+// without a board line every word buildable from the rack is scored by
+// its tile values alone. With a line, every placement is tried; placements
+// must use at least one rack tile, must not run into placed letters at
+// either end, and must use a placed letter if the line has any.
+func findScrabblePlays(words []string, rack string, line []scrabbleCell) []scrabblePlay {
+	rackCounts := runeCounts(rack)
+	blanks := strings.Count(rack, string(MASK_CHAR))
+	rackSize := len([]rune(rack))
+
+	if line == nil {
+		line = make([]scrabbleCell, rackSize)
+		for index := range line {
+			line[index] = scrabbleCell{LetterMult: 1, WordMult: 1}
+		}
+	}
+	var hasPlaced bool
+	for _, cell := range line {
+		if cell.Letter != 0 {
+			hasPlaced = true
+		}
+	}
+
+	var output []scrabblePlay
+	for _, word := range words {
+		runes := []rune(word)
+		best := scrabblePlay{Score: -1}
+		for start := 0; start+len(runes) <= len(line); start++ {
+			if start > 0 && line[start-1].Letter != 0 {
+				continue
+			}
+			if end := start + len(runes); end < len(line) && line[end].Letter != 0 {
+				continue
+			}
+			score, ok := scoreScrabblePlacement(runes, line[start:start+len(runes)], rackCounts, blanks, rackSize, hasPlaced)
+			if ok && score > best.Score {
+				best = scrabblePlay{Word: word, Start: start, Score: score}
+			}
+		}
+		if best.Score >= 0 {
+			output = append(output, best)
+		}
+	}
+	sort.SliceStable(output, func(i, j int) bool {
+		return output[i].Score > output[j].Score
+	})
+	return output
+}
+
+// scoreScrabblePlacement scores a word placed over the given cells.
+//
+// blanks are assigned to whichever copies of a short letter sit on the
+// cheapest squares, so the best possible score is returned.
+func scoreScrabblePlacement(word []rune, cells []scrabbleCell, rackCounts map[rune]int, blanks, rackSize int, mustConnect bool) (int, bool) {
+	var connected bool
+	newPositions := make(map[rune][]int)
+	var tilesUsed int
+	for index, r := range word {
+		if placed := cells[index].Letter; placed != 0 {
+			if placed != r {
+				return 0, false
+			}
+			connected = true
+			continue
+		}
+		newPositions[r] = append(newPositions[r], index)
+		tilesUsed++
+	}
+	if tilesUsed == 0 || (mustConnect && !connected) {
+		return 0, false
+	}
+
+	isBlank := make([]bool, len(word))
+	var blanksUsed int
+	for r, positions := range newPositions {
+		short := len(positions) - rackCounts[r]
+		if short <= 0 {
+			continue
+		}
+		blanksUsed += short
+		if blanksUsed > blanks {
+			return 0, false
+		}
+		sort.SliceStable(positions, func(i, j int) bool {
+			return cells[positions[i]].LetterMult < cells[positions[j]].LetterMult
+		})
+		for _, position := range positions[:short] {
+			isBlank[position] = true
+		}
+	}
+
+	var score int
+	wordMult := 1
+	for index, r := range word {
+		value := scrabbleTileValue(r)
+		if isBlank[index] {
+			value = 0
+		}
+		// premium squares only count for the tiles placed this turn.
+		if cells[index].Letter == 0 {
+			value *= cells[index].LetterMult
+			wordMult *= cells[index].WordMult
+		}
+		score += value
+	}
+	score *= wordMult
+	if rackSize == scrabbleRackSize && tilesUsed == scrabbleRackSize {
+		score += scrabbleBingoBonus
+	}
+	return score, true
+}
+
+// scrabbleTileValue returns the face value of a scrabble tile; see the
+// table above invertedScrabbleWeights.
+func scrabbleTileValue(c rune) int {
+	switch c {
+	case 'a', 'e', 'i', 'o', 'u', 'l', 'n', 's', 't', 'r':
+		return 1
+	case 'd', 'g':
+		return 2
+	case 'b', 'c', 'm', 'p':
+		return 3
+	case 'f', 'h', 'v', 'w', 'y':
+		return 4
+	case 'k':
+		return 5
+	case 'j', 'x':
+		return 8
+	case 'q', 'z':
+		return 10
+	default:
+		return 0
+	}
+}
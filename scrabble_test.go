@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_findScrabblePlays(t *testing.T) {
+	plays := findScrabblePlays([]string{"quiz", "quit", "zit", "quizzes"}, normalizeLetters("quiz?"), nil)
+	if fmt.Sprint(plays) != "[{quiz 0 22} {quit 0 12} {zit 0 11}]" {
+		t.Fatalf("expect blanks to score nothing, got %v", plays)
+	}
+
+	plays = findScrabblePlays([]string{"retains", "stain"}, "retains", nil)
+	if fmt.Sprint(plays) != "[{retains 0 57} {stain 0 5}]" {
+		t.Fatalf("expect the bingo bonus for using every tile, got %v", plays)
+	}
+}
+
+func Test_findScrabblePlays_line(t *testing.T) {
+	line, err := parseScrabbleLine(". DL . a . TW")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plays := findScrabblePlays([]string{"act", "at", "cat", "ct"}, "ct", line)
+	if fmt.Sprint(plays) != "[{act 3 15} {cat 2 5} {at 3 2}]" {
+		t.Fatalf("expect placements through the placed letter, got %v", plays)
+	}
+
+	plays = findScrabblePlays([]string{"act"}, normalizeLetters("c?"), line)
+	if fmt.Sprint(plays) != "[{act 3 12}]" {
+		t.Fatalf("expect a blank to still take the word multiplier, got %v", plays)
+	}
+
+	if _, err := parseScrabbleLine(". QW ."); err == nil {
+		t.Fatalf("expect an unknown cell to error")
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/urfave/cli/v2"
+)
+
+var shareCommand = &cli.Command{
+	Name:  "share",
+	Usage: "import constraints from a pasted share grid read from stdin",
+	Flags: []cli.Flag{
+		dictFlag(),
+		answersFlag(),
+		&cli.StringFlag{
+			Name:  "file",
+			Usage: "The path to read the share grid from (optional, will read stdin by default)",
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "If we should limit the number of results shown.",
+		},
+	},
+	Action: shareAction,
+}
+
+func shareAction(ctx *cli.Context) error {
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	answers, err := getAnswers(ctx.String("answers"), dict)
+	if err != nil {
+		return err
+	}
+	var input io.Reader = os.Stdin
+	if filePath := ctx.String("file"); filePath != "" {
+		f, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+	contents, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+	rows, err := parseShare(string(contents))
+	if err != nil {
+		return err
+	}
+
+	var b board
+	for _, row := range rows {
+		if row.Word == "" {
+			continue
+		}
+		if err := b.Add(row.Word, row.Feedback); err != nil {
+			return err
+		}
+	}
+	if len(b.Guesses) > 0 {
+		green, yellows, gray := b.Constraints()
+		fmt.Printf("--green %q --yellow %q --gray %q\n", string(green), strings.Join(yellows, ","), string(gray))
+	}
+
+	matched := shareCandidates(rows, wordRunes(sortedWords(dict)), b.Filter(wordRunes(answers)))
+	limit := ctx.Int("limit")
+	fmt.Printf("%d consistent answers\n", len(matched))
+	for index, word := range matched {
+		if limit > 0 && index >= limit {
+			break
+		}
+		fmt.Println(word)
+	}
+	return nil
+}
+
+// shareRow is a single row of a share grid, with the guessed word
+// if it was given alongside the emoji.
+type shareRow struct {
+	Word     string
+	Feedback []byte
+}
+
+// parseShare parses a pasted share block.
+//
+// lines without any emoji squares (e.g. the "Wordle 1,234 4/6*" header)
+// are skipped, and any letters on a row are taken as the guessed word.
+func parseShare(input string) ([]shareRow, error) {
+	var output []shareRow
+	var width int
+	for _, line := range strings.Split(input, "\n") {
+		var row shareRow
+		var word []rune
+		for _, c := range line {
+			if f, ok := feedbackEmoji(c); ok {
+				row.Feedback = append(row.Feedback, f)
+				continue
+			}
+			if unicode.IsLetter(c) {
+				word = append(word, unicode.ToLower(c))
+			}
+		}
+		if len(row.Feedback) == 0 {
+			continue
+		}
+		if width == 0 {
+			width = len(row.Feedback)
+		}
+		if len(row.Feedback) != width {
+			return nil, fmt.Errorf("share row %q has %d tiles, expected %d", line, len(row.Feedback), width)
+		}
+		if len(word) > 0 {
+			if len(word) != width {
+				return nil, fmt.Errorf("share row %q word doesn't match the number of tiles", line)
+			}
+			row.Word = string(word)
+		}
+		output = append(output, row)
+	}
+	if len(output) == 0 {
+		return nil, fmt.Errorf("no share rows found")
+	}
+	return output, nil
+}
+
+// shareCandidates returns the answers for which every row without a
+// known word could have been produced by some allowed guess.
+func shareCandidates(rows []shareRow, guesses, answers [][]rune) []string {
+	codes := make(Set[int])
+	for _, row := range rows {
+		if row.Word == "" {
+			codes.Add(encodeFeedback(row.Feedback))
+		}
+	}
+	consistent := make([]bool, len(answers))
+	p := newProgress("matching patterns", len(answers))
+	parallelFor(len(answers), func(index int) {
+		defer p.Inc()
+		for code := range codes {
+			var found bool
+			for _, guess := range guesses {
+				if len(guess) == len(answers[index]) && feedbackCode(guess, answers[index]) == code {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return
+			}
+		}
+		consistent[index] = true
+	})
+	p.Done()
+
+	var output []string
+	for index, answer := range answers {
+		if consistent[index] {
+			output = append(output, string(answer))
+		}
+	}
+	return output
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func Test_parseShare(t *testing.T) {
+	input := "Wordle 1,234 3/6*\n\n⬜🟦⬜⬜⬜ TARES\n🟧🟧⬛️⬛️⬛️\n🟩🟩🟩🟩🟩\n"
+	rows, err := parseShare(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("expect 3 rows, got %d", len(rows))
+	}
+	if rows[0].Word != "tares" || formatFeedback(rows[0].Feedback) != "xyxxx" {
+		t.Fatalf("expect the first row to be tares xyxxx, got %s %s", rows[0].Word, formatFeedback(rows[0].Feedback))
+	}
+	if rows[1].Word != "" || formatFeedback(rows[1].Feedback) != "ggxxx" {
+		t.Fatalf("expect the second row to be high contrast ggxxx, got %s", formatFeedback(rows[1].Feedback))
+	}
+
+	if _, err := parseShare("🟩🟩🟩🟩🟩\n🟩🟩🟩🟩\n"); err == nil {
+		t.Fatalf("expect rows of different widths to error")
+	}
+}
+
+func Test_shareCandidates(t *testing.T) {
+	rows := []shareRow{
+		{Feedback: []byte{feedbackGreen, feedbackGreen, feedbackGreen, feedbackGreen, feedbackGray}},
+		{Feedback: []byte{feedbackGreen, feedbackGreen, feedbackGreen, feedbackGreen, feedbackGreen}},
+	}
+	guesses := wordRunes([]string{"crane", "crank", "slosh"})
+
+	matched := shareCandidates(rows, guesses, wordRunes([]string{"crane", "slosh"}))
+	if len(matched) != 1 || matched[0] != "crane" {
+		t.Fatalf("expect only crane to be consistent with the patterns, got %v", matched)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// solverState is the solving state saved with `--state`, so a puzzle
+// can be picked up again later (or by someone else) without retyping
+// the constraints.
+type solverState struct {
+	Guesses []stateGuess `json:"guesses,omitempty"`
+	Green   string       `json:"green,omitempty"`
+	Yellow  []string     `json:"yellow,omitempty"`
+	Gray    string       `json:"gray,omitempty"`
+}
+
+// stateGuess is a guess and the feedback it received, e.g. "crane" and "gyxxx".
+type stateGuess struct {
+	Word     string `json:"word"`
+	Feedback string `json:"feedback"`
+}
+
+// loadState reads the state from a path, returning an empty state
+// if the file doesn't exist yet.
+func loadState(statePath string) (*solverState, error) {
+	contents, err := os.ReadFile(statePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return new(solverState), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s solverState
+	if err := json.Unmarshal(contents, &s); err != nil {
+		return nil, fmt.Errorf("invalid state file %q: %w", statePath, err)
+	}
+	return &s, nil
+}
+
+// Save writes the state to a path.
+func (s *solverState) Save(statePath string) error {
+	contents, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, append(contents, '\n'), 0644)
+}
+
+// AddGuess records a guess given in "word:feedback" form, e.g. "crane:gyxxx";
+// a guess already recorded with the same feedback is skipped, so running
+// a command again with a saved state doesn't repeat its guesses.
+func (s *solverState) AddGuess(input string) error {
+	word, feedback, ok := strings.Cut(input, ":")
+	if !ok {
+		return fmt.Errorf("invalid guess %q; expected the form 'word:feedback'", input)
+	}
+	parsed, err := parseFeedback(feedback)
+	if err != nil {
+		return err
+	}
+	if len([]rune(word)) != len(parsed) {
+		return fmt.Errorf("feedback %q doesn't match the length of guess %q", feedback, word)
+	}
+	guess := stateGuess{Word: strings.ToLower(word), Feedback: formatFeedback(parsed)}
+	for _, existing := range s.Guesses {
+		if existing == guess {
+			return nil
+		}
+	}
+	s.Guesses = append(s.Guesses, guess)
+	return nil
+}
+
+// Board returns the guesses as a board.
+func (s *solverState) Board() (board, error) {
+	var b board
+	for _, guess := range s.Guesses {
+		feedback, err := parseFeedback(guess.Feedback)
+		if err != nil {
+			return b, err
+		}
+		if err := b.Add(guess.Word, feedback); err != nil {
+			return b, err
+		}
+	}
+	return b, nil
+}
+
+// Merge folds constraints into the saved constraints, rejecting
+// greens that conflict with what's already known.
+func (s *solverState) Merge(green []rune, yellows []string, gray []rune) error {
+	merged, err := mergeGreen([]rune(s.Green), green)
+	if err != nil {
+		return err
+	}
+	if strings.Trim(string(merged), string(MASK_CHAR)) == "" {
+		merged = nil
+	}
+	s.Green = string(merged)
+
+	existingYellows := NewSet(s.Yellow)
+	for _, y := range yellows {
+		if y != "" && !existingYellows.Has(y) {
+			existingYellows.Add(y)
+			s.Yellow = append(s.Yellow, y)
+		}
+	}
+
+	existingGray := NewSet([]rune(s.Gray))
+	for _, g := range gray {
+		if !existingGray.Has(g) {
+			existingGray.Add(g)
+			s.Gray += string(g)
+		}
+	}
+	return nil
+}
+
+// mergeGreen combines two green masks position by position.
+func mergeGreen(a, b []rune) ([]rune, error) {
+	if len(a) == 0 {
+		return b, nil
+	}
+	if len(b) == 0 {
+		return a, nil
+	}
+	if len(a) != len(b) {
+		return nil, fmt.Errorf("green masks %q and %q are different lengths", string(a), string(b))
+	}
+	output := make([]rune, len(a))
+	for index := range a {
+		switch {
+		case a[index] == MASK_CHAR:
+			output[index] = b[index]
+		case b[index] == MASK_CHAR || b[index] == a[index]:
+			output[index] = a[index]
+		default:
+			return nil, fmt.Errorf("green masks %q and %q conflict at position %d", string(a), string(b), index+1)
+		}
+	}
+	return output, nil
+}
+
+// resolveConstraints combines the `--green`, `--yellow` and `--gray` flags
+// with constraints derived from any `--guess` flags and the `--state` file,
+// saving the combined state back to the file if one was given.
+func resolveConstraints(ctx *cli.Context) (green []rune, yellows []string, gray []rune, err error) {
+	s := new(solverState)
+	statePath := ctx.String("state")
+	if statePath != "" {
+		if s, err = loadState(statePath); err != nil {
+			return
+		}
+	}
+	for _, guess := range ctx.StringSlice("guess") {
+		if err = s.AddGuess(guess); err != nil {
+			return
+		}
+	}
+	var b board
+	if b, err = s.Board(); err != nil {
+		return
+	}
+	derivedGreen, derivedYellows, derivedGray := b.Constraints()
+	if err = s.Merge(derivedGreen, derivedYellows, derivedGray); err != nil {
+		return
+	}
+	if err = s.Merge([]rune(ctx.String("green")), ctx.StringSlice("yellow"), []rune(ctx.String("gray"))); err != nil {
+		return
+	}
+	if statePath != "" {
+		if err = s.Save(statePath); err != nil {
+			return
+		}
+	}
+	return []rune(s.Green), s.Yellow, []rune(s.Gray), nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func Test_solverState_roundTrip(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+
+	s, err := loadState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddGuess("tares:XYxxx"); err != nil {
+		t.Fatal(err)
+	}
+	// as when a command is run again with the same guess and state.
+	if err := s.AddGuess("TARES:xyxxx"); err != nil {
+		t.Fatal(err)
+	}
+	b, err := s.Board()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Merge(b.Constraints()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(statePath); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadState(statePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Guesses) != 1 || loaded.Guesses[0].Feedback != "xyxxx" {
+		t.Fatalf("expect the guess to be saved, got %v", loaded.Guesses)
+	}
+	if loaded.Green != "" || len(loaded.Yellow) != 1 || loaded.Yellow[0] != "_a___" || loaded.Gray != "tres" {
+		t.Fatalf("expect the derived constraints to be saved, got %+v", loaded)
+	}
+}
+
+func Test_solverState_Merge(t *testing.T) {
+	s := &solverState{Green: "c____", Yellow: []string{"_a___"}, Gray: "t"}
+	if err := s.Merge([]rune("_l___"), []string{"_a___", "___r_"}, []rune("ts")); err != nil {
+		t.Fatal(err)
+	}
+	if s.Green != "cl___" || len(s.Yellow) != 2 || s.Gray != "ts" {
+		t.Fatalf("expect constraints to merge without duplicates, got %+v", s)
+	}
+	if err := s.Merge([]rune("b____"), nil, nil); err == nil {
+		t.Fatalf("expect conflicting greens to error")
+	}
+}
+
+func Test_solverState_AddGuess_invalid(t *testing.T) {
+	s := new(solverState)
+	if err := s.AddGuess("tares"); err == nil {
+		t.Fatalf("expect a guess without feedback to error")
+	}
+	if err := s.AddGuess("tares:gy"); err == nil {
+		t.Fatalf("expect feedback of the wrong length to error")
+	}
+}
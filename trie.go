@@ -0,0 +1,47 @@
+package main
+
+// newTrie builds a prefix trie from a list of words.
+func newTrie(words []string) *trie {
+	root := new(trie)
+	for _, word := range words {
+		root.Add(word)
+	}
+	return root
+}
+
+// trie is a prefix tree of words, used to prune searches as soon as
+// a prefix can no longer lead to a word.
+type trie struct {
+	children map[rune]*trie
+	word     string
+}
+
+// Add adds a word to the trie.
+func (t *trie) Add(word string) {
+	node := t
+	for _, r := range word {
+		if node.children == nil {
+			node.children = make(map[rune]*trie)
+		}
+		child, ok := node.children[r]
+		if !ok {
+			child = new(trie)
+			node.children[r] = child
+		}
+		node = child
+	}
+	node.word = word
+}
+
+// Child returns the node for the prefix extended by a rune, or nil.
+func (t *trie) Child(r rune) *trie {
+	if t == nil {
+		return nil
+	}
+	return t.children[r]
+}
+
+// Word returns the word ending at this node, if any.
+func (t *trie) Word() string {
+	return t.word
+}
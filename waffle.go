@@ -0,0 +1,411 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/urfave/cli/v2"
+)
+
+// waffleSize is the width and height of a waffle grid.
+const waffleSize = 5
+
+var waffleCommand = &cli.Command{
+	Name:      "waffle",
+	Usage:     "solve a waffle grid from its tiles and colors, and find the fewest swaps to solve it",
+	ArgsUsage: "[row] [row] ... (or rows separated by '/')",
+	Description: "rows are 5 tiles wide, with any character (e.g. '.') for the four holes;\n" +
+		"colors are given the same way with 'g', 'y' and 'x' per tile, e.g. --colors 'gyxxg/x.y.g/...'",
+	Flags: []cli.Flag{
+		dictFlag(),
+		&cli.StringFlag{
+			Name:     "colors",
+			Usage:    "The color of each tile, as rows separated by '/' or spaces.",
+			Required: true,
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "The number of solutions to show.",
+			Value: 5,
+		},
+	},
+	Action: waffleAction,
+}
+
+func waffleAction(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return fmt.Errorf("expected the rows of the grid, e.g. 'fbeal/u.r.i/...'")
+	}
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	w, err := newWaffle(splitWaffleRows(ctx.Args().Slice()...), splitWaffleRows(ctx.String("colors")))
+	if err != nil {
+		return err
+	}
+	solutions := w.Solve(sortedWords(dict), ctx.Int("limit"))
+	if len(solutions) == 0 {
+		return fmt.Errorf("no solutions found")
+	}
+	for index, solution := range solutions {
+		if index > 0 {
+			fmt.Println()
+		}
+		fmt.Println(strings.Join(waffleWords(solution), " "))
+		fmt.Println(formatWaffle(solution))
+		swaps := minimumWaffleSwaps(w.Tiles, solution)
+		fmt.Printf("%d swaps:\n", len(swaps))
+		tiles := w.Tiles
+		for _, swap := range swaps {
+			a, b := &tiles[swap[0][0]][swap[0][1]], &tiles[swap[1][0]][swap[1][1]]
+			fmt.Printf("swap %d,%d (%c) with %d,%d (%c)\n", swap[0][0]+1, swap[0][1]+1, *a, swap[1][0]+1, swap[1][1]+1, *b)
+			*a, *b = *b, *a
+		}
+	}
+	return nil
+}
+
+func splitWaffleRows(args ...string) []string {
+	var rows []string
+	for _, arg := range args {
+		rows = append(rows, strings.FieldsFunc(arg, func(r rune) bool {
+			return r == '/' || unicode.IsSpace(r)
+		})...)
+	}
+	return rows
+}
+
+// waffleGrid holds a rune per cell, with zero for the holes.
+type waffleGrid [waffleSize][waffleSize]rune
+
+// isWaffleHole returns if a cell is one of the four holes in the grid.
+func isWaffleHole(row, col int) bool {
+	return row%2 == 1 && col%2 == 1
+}
+
+// isWaffleCrossing returns if a cell is shared by a row and a column word.
+func isWaffleCrossing(row, col int) bool {
+	return row%2 == 0 && col%2 == 0
+}
+
+// waffleSlots returns the cells of the six words; the rows 1, 3 and 5
+// then the columns 1, 3 and 5.
+func waffleSlots() [][][2]int {
+	var output [][][2]int
+	for line := 0; line < waffleSize; line += 2 {
+		var slot [][2]int
+		for index := 0; index < waffleSize; index++ {
+			slot = append(slot, [2]int{line, index})
+		}
+		output = append(output, slot)
+	}
+	for line := 0; line < waffleSize; line += 2 {
+		var slot [][2]int
+		for index := 0; index < waffleSize; index++ {
+			slot = append(slot, [2]int{index, line})
+		}
+		output = append(output, slot)
+	}
+	return output
+}
+
+// waffleWords returns the six words of a grid in slot order.
+func waffleWords(grid waffleGrid) []string {
+	var output []string
+	for _, slot := range waffleSlots() {
+		var word []rune
+		for _, cell := range slot {
+			word = append(word, grid[cell[0]][cell[1]])
+		}
+		output = append(output, string(word))
+	}
+	return output
+}
+
+func formatWaffle(grid waffleGrid) string {
+	var rows []string
+	for row := range grid {
+		var line []rune
+		for col, r := range grid[row] {
+			if isWaffleHole(row, col) {
+				r = ' '
+			}
+			line = append(line, r)
+		}
+		rows = append(rows, string(line))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// newWaffle returns a waffle from its tile rows and color rows.
+func newWaffle(rows, colorRows []string) (*waffle, error) {
+	if len(rows) != waffleSize || len(colorRows) != waffleSize {
+		return nil, fmt.Errorf("expected %d rows of tiles and colors, got %d and %d", waffleSize, len(rows), len(colorRows))
+	}
+	w := new(waffle)
+	for row := range rows {
+		tiles := []rune(strings.ToLower(rows[row]))
+		colors, err := parseFeedback(colorRows[row])
+		if err != nil {
+			return nil, err
+		}
+		if len(tiles) != waffleSize || len(colors) != waffleSize {
+			return nil, fmt.Errorf("row %d must have %d tiles and %d colors", row+1, waffleSize, waffleSize)
+		}
+		for col := range tiles {
+			if isWaffleHole(row, col) {
+				continue
+			}
+			w.Tiles[row][col] = tiles[col]
+			w.Colors[row][col] = colors[col]
+		}
+	}
+	return w, nil
+}
+
+// waffle is a scrambled waffle grid and the color of each tile.
+type waffle struct {
+	Tiles  waffleGrid
+	Colors [waffleSize][waffleSize]byte
+}
+
+// Candidates returns the words that could fill each slot judging by
+// the colors of the tiles in that slot alone.
+//
+// a tile that isn't green can't be in the right place, a yellow tile
+// only in one word must appear elsewhere in that word, and a gray tile
+// can't appear in the word's open cells more often than it's yellow there.
+func (w *waffle) Candidates(words []string) [][][]rune {
+	output := make([][][]rune, 0, 6)
+	for _, slot := range waffleSlots() {
+		green := make([]rune, waffleSize)
+		yellows := make(map[rune]int)
+		for index, cell := range slot {
+			green[index] = MASK_CHAR
+			switch w.Colors[cell[0]][cell[1]] {
+			case feedbackGreen:
+				green[index] = w.Tiles[cell[0]][cell[1]]
+			case feedbackYellow:
+				yellows[w.Tiles[cell[0]][cell[1]]]++
+			}
+		}
+
+		var candidates [][]rune
+		for _, word := range words {
+			runes := []rune(word)
+			if len(runes) != waffleSize || !greenMatches(green, runes) {
+				continue
+			}
+			open := make(map[rune]int)
+			for index, r := range runes {
+				if green[index] == MASK_CHAR {
+					open[r]++
+				}
+			}
+			valid := true
+			for index, cell := range slot {
+				tile := w.Tiles[cell[0]][cell[1]]
+				switch w.Colors[cell[0]][cell[1]] {
+				case feedbackGreen:
+					continue
+				case feedbackYellow:
+					if !isWaffleCrossing(cell[0], cell[1]) && open[tile] == 0 {
+						valid = false
+					}
+				case feedbackGray:
+					if open[tile] > yellows[tile] {
+						valid = false
+					}
+				}
+				if runes[index] == tile {
+					valid = false
+				}
+				if !valid {
+					break
+				}
+			}
+			if valid {
+				candidates = append(candidates, runes)
+			}
+		}
+		output = append(output, candidates)
+	}
+	return output
+}
+
+// Solve returns the solved grids that use exactly the tiles and would
+// give exactly the colors, stopping after `limit` if limit is positive.
+func (w *waffle) Solve(words []string, limit int) []waffleGrid {
+	slots := waffleSlots()
+	candidates := w.Candidates(words)
+	remaining := make(map[rune]int)
+	for row := range w.Tiles {
+		for col, r := range w.Tiles[row] {
+			if !isWaffleHole(row, col) {
+				remaining[r]++
+			}
+		}
+	}
+
+	var output []waffleGrid
+	var solution waffleGrid
+	var walk func(slot int) bool
+	walk = func(slot int) bool {
+		if slot == len(slots) {
+			if waffleColors(w.Tiles, solution) == w.Colors {
+				output = append(output, solution)
+			}
+			return limit > 0 && len(output) >= limit
+		}
+		for _, word := range candidates[slot] {
+			var placed [][2]int
+			fits := true
+			for index, cell := range slots[slot] {
+				r := word[index]
+				if existing := solution[cell[0]][cell[1]]; existing != 0 {
+					if existing != r {
+						fits = false
+						break
+					}
+					continue
+				}
+				if remaining[r] == 0 {
+					fits = false
+					break
+				}
+				remaining[r]--
+				solution[cell[0]][cell[1]] = r
+				placed = append(placed, cell)
+			}
+			done := fits && walk(slot+1)
+			for _, cell := range placed {
+				remaining[solution[cell[0]][cell[1]]]++
+				solution[cell[0]][cell[1]] = 0
+			}
+			if done {
+				return true
+			}
+		}
+		return false
+	}
+	walk(0)
+	return output
+}
+
+// waffleColors returns the colors the tiles would get against a solution.
+//
+// tiles in the right place are green; the rest are read row by row, and a
+// tile is yellow if its letter is still unclaimed in the open cells of
+// either word through it, claiming it from the first such word.
+func waffleColors(tiles, solution waffleGrid) (output [waffleSize][waffleSize]byte) {
+	slots := waffleSlots()
+	unclaimed := make([]map[rune]int, len(slots))
+	slotsOf := make(map[[2]int][]int)
+	for index, slot := range slots {
+		unclaimed[index] = make(map[rune]int)
+		for _, cell := range slot {
+			slotsOf[cell] = append(slotsOf[cell], index)
+			if tiles[cell[0]][cell[1]] != solution[cell[0]][cell[1]] {
+				unclaimed[index][solution[cell[0]][cell[1]]]++
+			}
+		}
+	}
+	for row := range tiles {
+		for col, tile := range tiles[row] {
+			if isWaffleHole(row, col) {
+				continue
+			}
+			if tile == solution[row][col] {
+				output[row][col] = feedbackGreen
+				continue
+			}
+			for _, slot := range slotsOf[[2]int{row, col}] {
+				if unclaimed[slot][tile] > 0 {
+					unclaimed[slot][tile]--
+					output[row][col] = feedbackYellow
+					break
+				}
+			}
+		}
+	}
+	return
+}
+
+// minimumWaffleSwaps returns the fewest swaps of two tiles that turn the
+// tiles into the solution.
+//
+// the misplaced tiles split into cycles, where each tile holds the letter
+// the next one needs, and a cycle of n tiles takes n-1 swaps; so the
+// fewest swaps comes from the most cycles, which is found by a depth
+// first search that skips tiles interchangeable with one already tried.
+func minimumWaffleSwaps(tiles, solution waffleGrid) [][2][2]int {
+	var misplaced [][2]int
+	for row := range tiles {
+		for col := range tiles[row] {
+			if !isWaffleHole(row, col) && tiles[row][col] != solution[row][col] {
+				misplaced = append(misplaced, [2]int{row, col})
+			}
+		}
+	}
+	tile := func(index int) rune { return tiles[misplaced[index][0]][misplaced[index][1]] }
+	target := func(index int) rune { return solution[misplaced[index][0]][misplaced[index][1]] }
+
+	used := make([]bool, len(misplaced))
+	var cycles, best [][]int
+	var search func(remaining int)
+	var extend func(path []int, remaining int)
+	search = func(remaining int) {
+		if best != nil && len(cycles)+remaining/2 <= len(best) {
+			return
+		}
+		first := -1
+		for index := range misplaced {
+			if !used[index] {
+				first = index
+				break
+			}
+		}
+		if first < 0 {
+			best = make([][]int, len(cycles))
+			copy(best, cycles)
+			return
+		}
+		used[first] = true
+		extend([]int{first}, remaining-1)
+		used[first] = false
+	}
+	extend = func(path []int, remaining int) {
+		need := target(path[len(path)-1])
+		tried := make(Set[[2]rune])
+		for index := range misplaced {
+			key := [2]rune{tile(index), target(index)}
+			if used[index] || key[0] != need || tried.Has(key) {
+				continue
+			}
+			tried.Add(key)
+			used[index] = true
+			next := append(path[:len(path):len(path)], index)
+			// closing the cycle as soon as it can be closed never costs
+			// a cycle, as the rest of a longer cycle would close too.
+			if tile(path[0]) == target(index) {
+				cycles = append(cycles, next)
+				search(remaining - 1)
+				cycles = cycles[:len(cycles)-1]
+			} else {
+				extend(next, remaining-1)
+			}
+			used[index] = false
+		}
+	}
+	search(len(misplaced))
+
+	var output [][2][2]int
+	for _, cycle := range best {
+		for index := 0; index+1 < len(cycle); index++ {
+			output = append(output, [2][2]int{misplaced[cycle[index]], misplaced[cycle[index+1]]})
+		}
+	}
+	return output
+}
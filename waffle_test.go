@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_waffle(t *testing.T) {
+	solved, err := newWaffle(splitWaffleRows("crane/r.d.v/alone/n.r.n/event"), splitWaffleRows("ggggg/g.g.g/ggggg/g.g.g/ggggg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if words := strings.Join(waffleWords(solved.Tiles), ","); words != "crane,alone,event,crane,adore,event" {
+		t.Fatalf("expect the six words in slot order, got %s", words)
+	}
+
+	w := &waffle{Tiles: solved.Tiles}
+	swap := func(a, b [2]int) {
+		w.Tiles[a[0]][a[1]], w.Tiles[b[0]][b[1]] = w.Tiles[b[0]][b[1]], w.Tiles[a[0]][a[1]]
+	}
+	swap([2]int{0, 1}, [2]int{2, 3})
+	swap([2]int{4, 1}, [2]int{1, 2})
+	swap([2]int{0, 0}, [2]int{4, 4})
+	swap([2]int{0, 0}, [2]int{2, 2})
+	w.Colors = waffleColors(w.Tiles, solved.Tiles)
+	if w.Colors == solved.Colors {
+		t.Fatalf("expect the scrambled tiles to not be all green")
+	}
+
+	words := []string{"crane", "alone", "event", "adore", "every", "above", "crate", "atone", "evens", "adorn"}
+	solutions := w.Solve(words, 0)
+	if len(solutions) != 1 || solutions[0] != solved.Tiles {
+		t.Fatalf("expect the original grid as the only solution, got %v", solutions)
+	}
+
+	swaps := minimumWaffleSwaps(w.Tiles, solutions[0])
+	if len(swaps) != 4 {
+		t.Fatalf("expect 4 swaps, got %d", len(swaps))
+	}
+	for _, s := range swaps {
+		swap(s[0], s[1])
+	}
+	if w.Tiles != solved.Tiles {
+		t.Fatalf("expect the swaps to solve the grid, got\n%s", formatWaffle(w.Tiles))
+	}
+}
+
+func Test_newWaffle_invalid(t *testing.T) {
+	if _, err := newWaffle(splitWaffleRows("crane r.d.v alone"), splitWaffleRows("ggggg g.g.g ggggg")); err == nil {
+		t.Fatalf("expect too few rows to error")
+	}
+	if _, err := newWaffle(splitWaffleRows("crane/r.d.v/alone/n.r.n/event"), splitWaffleRows("ggggg/g.g.g/ggggg/g.g.g/gggg")); err == nil {
+		t.Fatalf("expect a short row of colors to error")
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+var xordleCommand = &cli.Command{
+	Name:  "xordle",
+	Usage: "solve xordle, where two hidden words with no letters in common share one feedback row",
+	Flags: []cli.Flag{
+		dictFlag(),
+		answersFlag(),
+		&cli.StringSliceFlag{
+			Name:  "guess",
+			Usage: "A guess and its combined feedback in 'word:feedback' form, e.g. 'crane:xgyxx' (can be multiple!)",
+		},
+		&cli.IntFlag{
+			Name:  "sample",
+			Usage: "The most pairs to score suggestions against; larger pair spaces are sampled evenly.",
+			Value: 20000,
+		},
+		&cli.IntFlag{
+			Name:  "limit",
+			Usage: "The number of pairs and suggestions to show.",
+			Value: 10,
+		},
+	},
+	Action: xordleAction,
+}
+
+func xordleAction(ctx *cli.Context) error {
+	dict, err := getDictionary(ctx.String("dict"))
+	if err != nil {
+		return err
+	}
+	answers, err := getAnswers(ctx.String("answers"), dict)
+	if err != nil {
+		return err
+	}
+	var state solverState
+	for _, guess := range ctx.StringSlice("guess") {
+		if err := state.AddGuess(guess); err != nil {
+			return err
+		}
+	}
+	b, err := state.Board()
+	if err != nil {
+		return err
+	}
+
+	words := xordleCandidates(wordRunes(answers), b)
+	pairs, total := xordlePairs(words, b, ctx.Int("sample"))
+	limit := ctx.Int("limit")
+	fmt.Printf("%d pairs\n", total)
+	for index, pair := range pairs {
+		if limit > 0 && index >= limit {
+			fmt.Println("...")
+			break
+		}
+		fmt.Printf("%s + %s\n", string(words[pair[0]]), string(words[pair[1]]))
+	}
+	if total <= 1 {
+		return nil
+	}
+
+	guesses := sortedWords(dict)
+	ranked := rankGuesses(guesses, nil, xordleScorer(words, pairs), newProgress("ranking guesses", len(guesses)))
+	fmt.Println("\nsuggestions:")
+	printRanked(ranked, limit)
+	return nil
+}
+
+// combineFeedbackCodes returns the feedback code xordle shows for two
+// hidden words, which is the best colour either word gives each tile.
+func combineFeedbackCodes(a, b, length int) int {
+	var output int
+	place := 1
+	for position := 0; position < length; position++ {
+		aDigit, bDigit := a%3, b%3
+		if bDigit > aDigit {
+			aDigit = bDigit
+		}
+		output += aDigit * place
+		a, b, place = a/3, b/3, place*3
+	}
+	return output
+}
+
+// letterMask returns a bitmask of the letters a-z in a word.
+func letterMask(word []rune) uint32 {
+	var output uint32
+	for _, r := range word {
+		if r >= 'a' && r <= 'z' {
+			output |= 1 << (r - 'a')
+		}
+	}
+	return output
+}
+
+// xordleCandidates returns the words that could be one of the pair.
+//
+// as the combined feedback is the best colour of either word, a word
+// can't give any tile a better colour than the feedback shows.
+func xordleCandidates(words [][]rune, b board) [][]rune {
+	var output [][]rune
+	var stack [16]byte
+	for _, word := range words {
+		valid := true
+		for index, guess := range b.Guesses {
+			guessRunes := []rune(guess)
+			if len(guessRunes) != len(word) || len(guessRunes) > len(stack) {
+				valid = false
+				break
+			}
+			actual := stack[:len(guessRunes)]
+			fillFeedback(actual, guessRunes, word)
+			for position, f := range actual {
+				if f > b.Feedback[index][position] {
+					valid = false
+					break
+				}
+			}
+			if !valid {
+				break
+			}
+		}
+		if valid {
+			output = append(output, word)
+		}
+	}
+	return output
+}
+
+// xordlePairs returns the pairs of word indexes with no letters in common
+// whose combined feedback matches every row, along with how many there
+// are in total; when there are more than `sample` pairs, an even spread
+// of `sample` of them is returned.
+func xordlePairs(words [][]rune, b board, sample int) ([][2]int, int) {
+	masks := make([]uint32, len(words))
+	codes := make([][]int, len(words))
+	for index, word := range words {
+		masks[index] = letterMask(word)
+		for _, guess := range b.Guesses {
+			codes[index] = append(codes[index], feedbackCode([]rune(guess), word))
+		}
+	}
+	expected := make([]int, len(b.Guesses))
+	for index, feedback := range b.Feedback {
+		expected[index] = encodeFeedback(feedback)
+	}
+	matches := func(i, j int) bool {
+		if masks[i]&masks[j] != 0 || len(words[i]) != len(words[j]) {
+			return false
+		}
+		for row, code := range expected {
+			if combineFeedbackCodes(codes[i][row], codes[j][row], len(b.Feedback[row])) != code {
+				return false
+			}
+		}
+		return true
+	}
+
+	var total int
+	for i := range words {
+		for j := i + 1; j < len(words); j++ {
+			if matches(i, j) {
+				total++
+			}
+		}
+	}
+	var output [][2]int
+	var seen int
+	for i := range words {
+		for j := i + 1; j < len(words); j++ {
+			if !matches(i, j) {
+				continue
+			}
+			if sample <= 0 || total <= sample || seen*sample/total != (seen+1)*sample/total {
+				output = append(output, [2]int{i, j})
+			}
+			seen++
+		}
+	}
+	return output, total
+}
+
+// xordleScorer returns a scorer giving the entropy of the combined
+// feedback a guess would get across the pairs; the candidates the
+// scorer is passed are ignored.
+//
+// the combined codes are looked up from a table built once, as the
+// pairs far outnumber the distinct codes.
+func xordleScorer(words [][]rune, pairs [][2]int) scorer {
+	var length int
+	if len(words) > 0 {
+		length = len(words[0])
+	}
+	size := 1
+	for position := 0; position < length; position++ {
+		size *= 3
+	}
+	combined := make([]int, size*size)
+	for a := 0; a < size; a++ {
+		for b := 0; b < size; b++ {
+			combined[a*size+b] = combineFeedbackCodes(a, b, length)
+		}
+	}
+	return func(guess []rune, _ [][]rune) float64 {
+		if len(guess) != length {
+			return 0
+		}
+		codes := make([]int, len(words))
+		for index, word := range words {
+			codes[index] = feedbackCode(guess, word)
+		}
+		counts := make(map[int]int)
+		for _, pair := range pairs {
+			counts[combined[codes[pair[0]]*size+codes[pair[1]]]]++
+		}
+		return entropy(counts)
+	}
+}
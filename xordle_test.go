@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+)
+
+func Test_combineFeedbackCodes(t *testing.T) {
+	a := encodeFeedback([]byte{feedbackGray, feedbackGreen, feedbackYellow})
+	b := encodeFeedback([]byte{feedbackYellow, feedbackGray, feedbackGreen})
+	expected := encodeFeedback([]byte{feedbackYellow, feedbackGreen, feedbackGreen})
+	if combined := combineFeedbackCodes(a, b, 3); combined != expected {
+		t.Fatalf("expect the best colour per tile, got code %d", combined)
+	}
+}
+
+func Test_xordlePairs(t *testing.T) {
+	words := wordRunes([]string{"cat", "dog", "pig", "cow", "fox"})
+
+	pairs, total := xordlePairs(words, board{}, 0)
+	if total != 5 || len(pairs) != 5 {
+		t.Fatalf("expect 5 pairs with no letters in common, got %d", total)
+	}
+	pairs, total = xordlePairs(words, board{}, 2)
+	if total != 5 || len(pairs) != 2 {
+		t.Fatalf("expect a sample of 2 of the 5 pairs, got %d of %d", len(pairs), total)
+	}
+
+	var b board
+	if err := b.Add("cot", []byte{feedbackGreen, feedbackGreen, feedbackGreen}); err != nil {
+		t.Fatal(err)
+	}
+	candidates := xordleCandidates(words, b)
+	pairs, total = xordlePairs(candidates, b, 0)
+	if total != 2 {
+		t.Fatalf("expect 2 pairs, got %d", total)
+	}
+	for _, pair := range pairs {
+		if string(candidates[pair[0]]) != "cat" {
+			t.Fatalf("expect every pair to include cat, got %s + %s", string(candidates[pair[0]]), string(candidates[pair[1]]))
+		}
+	}
+
+	score := xordleScorer(words, [][2]int{{0, 1}, {0, 2}})
+	if score([]rune("dig"), nil) != 1 {
+		t.Fatalf("expect a guess that splits two pairs to score 1 bit")
+	}
+}